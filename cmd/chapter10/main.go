@@ -1,6 +1,7 @@
 package main
 
 import (
+	"log"
 	m "math"
 	"path"
 
@@ -25,62 +26,75 @@ func main() {
 	// set up scene
 	s := scene.NewScene()
 
+	floorShader, err := shaders.With(
+		math.Scale(1.5, 1.5, 1.5), //.RotateY(m.Pi/4),
+		shaders.Perturbed(
+			0.2,
+			10,
+			shaders.Cubes(
+				shaders.Pigment(color.Black),
+				shaders.Pigment(color.White),
+			),
+		),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	floorMaterial := material.NewMaterial().
 		SetAmbient(0.1).
 		SetDiffuse(1.0).
-		SetShader(
-			shaders.With(
-				math.Scale(1.5, 1.5, 1.5), //.RotateY(m.Pi/4),
-				shaders.Perturbed(
-					0.2,
-					10,
-					shaders.Cubes(
-						shaders.Pigment(color.Black),
-						shaders.Pigment(color.White),
-					),
-				),
-			),
-		)
+		SetShader(floorShader)
 
 	s.Add(
 		entities.NewPlane().
 			AddComponent(floorMaterial),
 	)
 
+	wallShader1, err := shaders.With(
+		math.Scale(0.5, 0.5, 0.5).RotateY(m.Pi/4),
+		shaders.Cubes(
+			shaders.Pigment(color.White),
+			shaders.Pigment(color.Black),
+		),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	wallMaterial1 := material.NewMaterial().
 		SetAmbient(0.1).
 		SetDiffuse(1.0).
-		SetShader(
-			shaders.With(
-				math.Scale(0.5, 0.5, 0.5).RotateY(m.Pi/4),
-				shaders.Cubes(
-					shaders.Pigment(color.White),
-					shaders.Pigment(color.Black),
-				),
+		SetShader(wallShader1)
+
+	greenStripes, err := shaders.With(
+		math.RotateZ(m.Pi/2),
+		shaders.Stripes(
+			shaders.Pigment(color.White),
+			shaders.Pigment(color.Green),
+		),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	wallShader2, err := shaders.With(
+		math.RotateZ(m.Pi/4).Scale(1, 1, 1),
+		shaders.Blend(
+			shaders.Stripes(
+				shaders.Pigment(color.White),
+				shaders.Pigment(color.Red),
 			),
-		)
+			greenStripes,
+		),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	wallMaterial2 := material.NewMaterial().
 		SetAmbient(0.1).
 		SetDiffuse(1.0).
-		SetShader(
-			shaders.With(
-				math.RotateZ(m.Pi/4).Scale(1, 1, 1),
-				shaders.Blend(
-					shaders.Stripes(
-						shaders.Pigment(color.White),
-						shaders.Pigment(color.Red),
-					),
-					shaders.With(
-						math.RotateZ(m.Pi/2),
-						shaders.Stripes(
-							shaders.Pigment(color.White),
-							shaders.Pigment(color.Green),
-						),
-					),
-				),
-			),
-		)
+		SetShader(wallShader2)
 
 	s.Add(
 		entities.NewPlane().
@@ -98,15 +112,18 @@ func main() {
 			AddComponent(wallMaterial2),
 	)
 
+	noise, err := shaders.With(
+		math.Scale(0.1, 0.1, 0.1),
+		shaders.OpenSimplex(),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	s.Add(
 		entities.NewSphere().
 			AddComponent(
-				material.NewMaterial().SetShader(
-					shaders.With(
-						math.Scale(0.1, 0.1, 0.1),
-						shaders.OpenSimplex(),
-					),
-				),
+				material.NewMaterial().SetShader(noise),
 			).
 			Translate(0, 0.5, 0),
 	)
@@ -129,5 +146,7 @@ func main() {
 		)
 
 	filepath := path.Join(OUTPUT_DIR, "chapter10.png")
-	c.SaveFrame(s, filepath)
+	if err := c.SaveFrame(s, filepath); err != nil {
+		log.Fatal(err)
+	}
 }
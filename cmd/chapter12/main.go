@@ -1,6 +1,7 @@
 package main
 
 import (
+	"log"
 	m "math"
 
 	"github.com/bricef/ray-tracer/pkg/camera"
@@ -21,18 +22,21 @@ func main() {
 	// set up scene
 	s := scene.NewScene()
 
+	floorShader, err := shaders.With(
+		math.Scale(1.5, 1.5, 1.5), //.RotateY(m.Pi/4),
+		shaders.Cubes(
+			shaders.Pigment(color.Black),
+			shaders.Pigment(color.White),
+		),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	floorMaterial := material.NewMaterial().
 		SetAmbient(0.1).
 		SetDiffuse(1.0).
-		SetShader(
-			shaders.With(
-				math.Scale(1.5, 1.5, 1.5), //.RotateY(m.Pi/4),
-				shaders.Cubes(
-					shaders.Pigment(color.Black),
-					shaders.Pigment(color.White),
-				),
-			),
-		)
+		SetShader(floorShader)
 
 	wallMaterial := material.NewMaterial()
 	wallMaterial.SetColor(color.New(1, 0.9, 0.9))
@@ -80,5 +84,7 @@ func main() {
 				math.NewVector(0, 0, 1)),
 		)
 
-	c.SaveFrame(s, "output/chapter12.png")
+	if err := c.SaveFrame(s, "output/chapter12.png"); err != nil {
+		log.Fatal(err)
+	}
 }
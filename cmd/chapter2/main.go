@@ -1,6 +1,8 @@
 package main
 
 import (
+	"log"
+
 	"github.com/bricef/ray-tracer/pkg/canvas"
 	"github.com/bricef/ray-tracer/pkg/color"
 )
@@ -14,5 +16,7 @@ func main() {
 	}
 	c.Set(0, 0, color.New(1, 1, 1))
 
-	c.WritePNG("test.png")
+	if err := c.WritePNG("test.png"); err != nil {
+		log.Fatal(err)
+	}
 }
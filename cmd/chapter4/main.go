@@ -1,6 +1,7 @@
 package main
 
 import (
+	"log"
 	"math"
 
 	"github.com/bricef/ray-tracer/pkg/canvas"
@@ -21,6 +22,8 @@ func main() {
 
 	}
 
-	c.WritePNG("output/chapter4.png")
+	if err := c.WritePNG("output/chapter4.png"); err != nil {
+		log.Fatal(err)
+	}
 
 }
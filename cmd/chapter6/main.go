@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"path"
 
 	"github.com/bricef/ray-tracer/pkg/camera"
@@ -44,12 +45,16 @@ func main() {
 		light,
 	}
 
-	camera.Render(frame, scene, lights)
+	if err := camera.Render(frame, scene, lights); err != nil {
+		log.Fatal(err)
+	}
 
 	// Write out to file
 	OUTPUT_DIR := "output"
 	utils.EnsureDir(OUTPUT_DIR)
 	outputFilename := path.Join(OUTPUT_DIR, "chapter6.png")
-	frame.WritePNG(outputFilename)
+	if err := frame.WritePNG(outputFilename); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("Wrote output to %v", outputFilename)
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	m "math"
 	"path"
 
@@ -17,11 +18,16 @@ import (
 	"github.com/bricef/ray-tracer/pkg/utils"
 )
 
-func saveFrame(frame *canvas.ImageCanvas, c *camera.Camera, s *scene.Scene, filepath string) {
+func saveFrame(frame *canvas.ImageCanvas, c *camera.Camera, s *scene.Scene, filepath string) error {
 
-	c.Render(s, frame)
-	frame.WritePNG(filepath)
+	if err := c.Render(s, frame); err != nil {
+		return err
+	}
+	if err := frame.WritePNG(filepath); err != nil {
+		return err
+	}
 	fmt.Printf("Wrote output to %v\n", filepath)
+	return nil
 }
 
 func main() {
@@ -78,7 +84,9 @@ func main() {
 	for tick := 0; tick <= MAX_TICKS; tick += 1 {
 		s.Tick()
 		filepath := path.Join(OUTPUT_DIR, fmt.Sprintf("frame-%v.png", tick))
-		saveFrame(frame, c, s, filepath)
+		if err := saveFrame(frame, c, s, filepath); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	// Write out to file
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"log"
 	m "math"
 	"path"
 
@@ -96,6 +97,8 @@ func main() {
 		)
 
 	filepath := path.Join(OUTPUT_DIR, "chapter8-multilight.png")
-	c.SaveFrame(s, filepath)
+	if err := c.SaveFrame(s, filepath); err != nil {
+		log.Fatal(err)
+	}
 
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	m "math"
 	"path"
 
@@ -92,12 +93,16 @@ func main() {
 				math.NewVector(0, 1, 0)),
 		)
 
-	c.Render(s, frame)
+	if err := c.Render(s, frame); err != nil {
+		log.Fatal(err)
+	}
 
 	// Write out to file
 	OUTPUT_DIR := "output"
 	utils.EnsureDir(OUTPUT_DIR)
 	outputFilename := path.Join(OUTPUT_DIR, "chapter8.png")
-	frame.WritePNG(outputFilename)
+	if err := frame.WritePNG(outputFilename); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Printf("Wrote output to %v", outputFilename)
 }
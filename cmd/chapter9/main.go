@@ -1,6 +1,7 @@
 package main
 
 import (
+	"log"
 	m "math"
 	"path"
 
@@ -59,5 +60,7 @@ func main() {
 		)
 
 	filepath := path.Join(OUTPUT_DIR, "chapter9.png")
-	c.SaveFrame(s, filepath)
+	if err := c.SaveFrame(s, filepath); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bricef/ray-tracer/pkg/loader"
+)
+
+func main() {
+	output := flag.String("o", "output/render.png", "file to write the rendered image to")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %v [-o output.{png,ppm,jpg,pfm,hdr}] scene.yml\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	s, c, err := loader.LoadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("%v: %v", flag.Arg(0), err)
+	}
+
+	if err := c.SaveFrame(s, *output); err != nil {
+		log.Fatalf("%v: %v", *output, err)
+	}
+}
@@ -10,4 +10,5 @@ require (
 	github.com/jinzhu/copier v0.3.2
 	github.com/mattn/go-isatty v0.0.14 // indirect
 	github.com/ojrac/opensimplex-go v1.0.2
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,211 @@
+package camera
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+// Pattern decides where the rays sampling a pixel are fired.
+type Pattern int
+
+const (
+	// Grid samples the center of each cell of a regular grid.
+	Grid Pattern = iota
+	// Jittered samples a random point in each cell of a regular grid.
+	Jittered
+	// Adaptive samples the corners of each cell of a regular grid and
+	// subdivides cells whose corners differ by more than a threshold.
+	Adaptive
+)
+
+// Filter is the reconstruction filter weighting the samples around the pixel
+// center. Wider filters sample over a larger area than the pixel itself.
+type Filter int
+
+const (
+	Box Filter = iota
+	Tent
+	Gaussian
+	Mitchell
+)
+
+// AntiAliasing configures supersampling of pixels.
+type AntiAliasing struct {
+	Pattern Pattern
+	// Number of grid cells along each side of a pixel
+	Samples int
+	Filter  Filter
+	// Largest difference in any channel between the corners of an adaptive
+	// cell before it is subdivided
+	Threshold float64
+	// Number of times an adaptive cell may be subdivided
+	Depth int
+}
+
+// radius is the half-width of the filter, in pixels.
+func (f Filter) radius() float64 {
+	switch f {
+	case Tent:
+		return 1.0
+	case Gaussian:
+		return 1.5
+	case Mitchell:
+		return 2.0
+	default:
+		return 0.5
+	}
+}
+
+// weight evaluates the separable filter at an offset from the pixel center.
+func (f Filter) weight(dx, dy float64) float64 {
+	return f.weight1D(dx) * f.weight1D(dy)
+}
+
+func (f Filter) weight1D(x float64) float64 {
+	r := f.radius()
+	x = m.Abs(x)
+	if x > r {
+		return 0
+	}
+	switch f {
+	case Tent:
+		return r - x
+	case Gaussian:
+		const alpha = 2.0
+		return m.Exp(-alpha*x*x) - m.Exp(-alpha*r*r)
+	case Mitchell:
+		// B = C = 1/3, stretched over [-2, 2]
+		const b, c = 1.0 / 3.0, 1.0 / 3.0
+		x = 2 * x / r
+		if x > 1 {
+			return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+		}
+		return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+	default:
+		return 1
+	}
+}
+
+// sample casts a ray through an offset from the center of pixel (x, y).
+func (c *Camera) sample(s *scene.Scene, x, y int, dx, dy float64) color.Color {
+	return c.cast(s, c.ProjectRay(float64(x)+0.5+dx, float64(y)+0.5+dy))
+}
+
+// PixelColor renders a single pixel, supersampling it if the camera has
+// anti-aliasing enabled.
+func (c *Camera) PixelColor(s *scene.Scene, x, y int) color.Color {
+	aa := c.AntiAliasing
+	if aa == nil {
+		return c.cast(s, c.ProjectPixelRay(x, y))
+	}
+
+	r := aa.Filter.radius()
+	n := int(m.Ceil(float64(aa.Samples) * 2 * r))
+	if n < 1 {
+		n = 1
+	}
+	cell := 2 * r / float64(n)
+
+	if aa.Pattern == Adaptive {
+		return c.adaptive(s, x, y, n, cell)
+	}
+
+	sum := color.Black
+	total := 0.0
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			u, v := 0.5, 0.5
+			if aa.Pattern == Jittered {
+				u, v = rand.Float64(), rand.Float64()
+			}
+			dx := -r + (float64(i)+u)*cell
+			dy := -r + (float64(j)+v)*cell
+			w := aa.Filter.weight(dx, dy)
+			if w == 0 {
+				continue
+			}
+			sum = sum.Add(c.sample(s, x, y, dx, dy).Scale(w))
+			total += w
+		}
+	}
+	return sum.Scale(1 / total)
+}
+
+// corner is a point of the finest adaptive lattice of a pixel.
+type corner struct {
+	i, j int
+}
+
+// adaptive samples the corners of an n x n grid over the filter support and
+// recursively splits the cells whose corners disagree. Each final cell
+// contributes the average of its corners, weighted by its area and the
+// filter at its center.
+func (c *Camera) adaptive(s *scene.Scene, x, y int, n int, cell float64) color.Color {
+	aa := c.AntiAliasing
+	r := aa.Filter.radius()
+	scale := 1 << uint(aa.Depth) // lattice steps per grid cell
+	step := cell / float64(scale)
+
+	samples := map[corner]color.Color{}
+	at := func(k corner) color.Color {
+		if v, ok := samples[k]; ok {
+			return v
+		}
+		v := c.sample(s, x, y, -r+float64(k.i)*step, -r+float64(k.j)*step)
+		samples[k] = v
+		return v
+	}
+
+	sum := color.Black
+	total := 0.0
+	var refine func(i, j, span int)
+	refine = func(i, j, span int) {
+		cs := [4]color.Color{
+			at(corner{i, j}),
+			at(corner{i + span, j}),
+			at(corner{i, j + span}),
+			at(corner{i + span, j + span}),
+		}
+		if span > 1 && differ(cs, aa.Threshold) {
+			half := span / 2
+			refine(i, j, half)
+			refine(i+half, j, half)
+			refine(i, j+half, half)
+			refine(i+half, j+half, half)
+			return
+		}
+		size := float64(span) * step
+		dx := -r + float64(i)*step + size/2
+		dy := -r + float64(j)*step + size/2
+		w := aa.Filter.weight(dx, dy) * size * size
+		if w == 0 {
+			return
+		}
+		avg := cs[0].Add(cs[1]).Add(cs[2]).Add(cs[3]).Scale(0.25)
+		sum = sum.Add(avg.Scale(w))
+		total += w
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			refine(i*scale, j*scale, scale)
+		}
+	}
+	return sum.Scale(1 / total)
+}
+
+// differ reports whether any two colors differ by more than the threshold in
+// any channel.
+func differ(cs [4]color.Color, threshold float64) bool {
+	for a := 0; a < len(cs); a++ {
+		for b := a + 1; b < len(cs); b++ {
+			d := cs[a].Sub(cs[b])
+			if m.Abs(d.R) > threshold || m.Abs(d.G) > threshold || m.Abs(d.B) > threshold {
+				return true
+			}
+		}
+	}
+	return false
+}
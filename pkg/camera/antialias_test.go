@@ -0,0 +1,80 @@
+package camera_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/camera"
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/scene"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+// edgeScene has a flat white wall covering world x < edge,
+// seen by a single pixel camera covering x in [-1, 1] at the wall depth.
+func edgeScene(edge float64) *scene.Scene {
+	s := scene.NewScene()
+	s.Add(lighting.NewPointLight(color.White))
+	wall := entities.NewCube().Translate(edge-50, 0, -2).Scale(50, 50, 1)
+	wall.GetMaterial().SetAmbient(1).SetDiffuse(0).SetSpecular(0)
+	s.Add(wall)
+	return s
+}
+
+func TestAntiAliasingUniformPixel(t *testing.T) {
+	s := scene.NewScene()
+	s.BackgroundColor = color.New(0.2, 0.4, 0.6)
+	c := camera.CameraFromFOV(1, 1, halfPi)
+
+	for _, pattern := range []camera.Pattern{camera.Grid, camera.Jittered, camera.Adaptive} {
+		for _, filter := range []camera.Filter{camera.Box, camera.Tent, camera.Gaussian, camera.Mitchell} {
+			c.SetAntiAliasing(camera.AntiAliasing{Pattern: pattern, Samples: 2, Filter: filter, Threshold: 0.1, Depth: 2})
+			result := c.PixelColor(s, 0, 0)
+			if !result.Equal(s.BackgroundColor) {
+				t.Errorf("Supersampling a uniform pixel with pattern %v and filter %v changed its color to %v", pattern, filter, result)
+			}
+		}
+	}
+}
+
+func TestAntiAliasingBlendsEdges(t *testing.T) {
+	s := edgeScene(0)
+	c := camera.CameraFromFOV(1, 1, halfPi)
+
+	if result := c.PixelColor(s, 0, 0); !result.Equal(color.White) && !result.Equal(color.Black) {
+		t.Errorf("Expected a single sample to be either side of the edge. Got %v", result)
+	}
+
+	half := color.New(0.5, 0.5, 0.5)
+	for _, filter := range []camera.Filter{camera.Box, camera.Tent, camera.Gaussian, camera.Mitchell} {
+		c.SetAntiAliasing(camera.AntiAliasing{Pattern: camera.Grid, Samples: 2, Filter: filter})
+		if result := c.PixelColor(s, 0, 0); !result.Equal(half) {
+			t.Errorf("Expected edge through the pixel center to blend evenly with filter %v. Got %v", filter, result)
+		}
+	}
+
+	c.SetAntiAliasing(camera.AntiAliasing{Pattern: camera.Jittered, Samples: 4, Filter: camera.Box})
+	if result := c.PixelColor(s, 0, 0); !result.Equal(half) {
+		t.Errorf("Expected jittered samples to blend the edge evenly. Got %v", result)
+	}
+}
+
+func TestAdaptiveAntiAliasingRefinesEdges(t *testing.T) {
+	// The wall covers 65% of the pixel
+	s := edgeScene(0.3)
+	c := camera.CameraFromFOV(1, 1, halfPi)
+
+	c.SetAntiAliasing(camera.AntiAliasing{Pattern: camera.Grid, Samples: 2, Filter: camera.Box})
+	coarse := c.PixelColor(s, 0, 0)
+
+	c.SetAntiAliasing(camera.AntiAliasing{Pattern: camera.Adaptive, Samples: 1, Filter: camera.Box, Threshold: 0.1, Depth: 5})
+	fine := c.PixelColor(s, 0, 0)
+
+	if !utils.EqualToTolerance(fine.R, 0.65, 0.02) {
+		t.Errorf("Expected adaptive sampling to find the edge coverage. Got %v", fine)
+	}
+	if utils.EqualToTolerance(coarse.R, 0.65, 0.1) {
+		t.Errorf("Expected a coarse grid to misjudge the edge coverage. Got %v", coarse)
+	}
+}
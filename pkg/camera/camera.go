@@ -1,9 +1,12 @@
 package camera
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	m "math"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"time"
 
@@ -27,6 +30,23 @@ type Camera struct {
 	Aspect      float64
 	HalfWidth   float64
 	HalfHeight  float64
+	// Supersampling of pixels. Nil fires a single ray through each pixel center.
+	AntiAliasing *AntiAliasing
+	// Radius of the lens. Zero is a pinhole camera with everything in focus.
+	Aperture float64
+	// Distance to the plane in perfect focus
+	FocalDistance float64
+	// Number of sides of a polygonal aperture. Less than 3 is a round aperture.
+	ApertureBlades int
+	// Number of goroutines rendering tiles. Zero uses one per CPU.
+	Workers int
+	// Edge length of the tiles, in pixels. Zero uses DefaultTileSize.
+	TileSize  int
+	TileOrder TileOrder
+	// Tone mapping of saved frames in low dynamic range formats
+	ToneMap canvas.ToneMap
+	// Computes the light arriving along camera rays. Nil uses the Whitted integrator.
+	Integrator scene.Integrator
 }
 
 func CameraFromFOV(w int, h int, fov float64) *Camera {
@@ -54,12 +74,22 @@ func CameraFromFOV(w int, h int, fov float64) *Camera {
 }
 
 func (c *Camera) ProjectPixelRay(u, v int) ray.Ray {
-	xoff := (float64(u) + 0.5) * c.PixelSize
-	yoff := (float64(v) + 0.5) * c.PixelSize
+	return c.ProjectRay(float64(u)+0.5, float64(v)+0.5)
+}
+
+// ProjectRay fires a ray through a point of the frame, in pixel units from
+// the top left corner.
+func (c *Camera) ProjectRay(x, y float64) ray.Ray {
+	xoff := x * c.PixelSize
+	yoff := y * c.PixelSize
 
 	worldX := c.HalfWidth - xoff
 	worldY := c.HalfHeight - yoff
 
+	if c.Aperture > 0 {
+		return c.lensRay(worldX, worldY)
+	}
+
 	pixel := c.Transform.Inverse().Apply(math.NewPoint(worldX, worldY, -c.Distance))
 	origin := c.Transform.Inverse().Apply(math.NewPoint(0, 0, 0)).AsPoint()
 	direction := pixel.Sub(origin).AsVector().Normalize()
@@ -74,110 +104,203 @@ func (c *Camera) SetTransform(t math.Transform) *Camera {
 	return c
 }
 
-type Result struct {
-	Pixel canvas.Pixel
-	Color color.Color
+// SetLens turns the camera into a thin lens camera. Objects away from the
+// focal distance are blurred, more so with a larger aperture. Each ray goes
+// through a random point of the lens, so this needs supersampling to
+// converge.
+func (c *Camera) SetLens(aperture float64, focalDistance float64) *Camera {
+	c.Aperture = aperture
+	c.FocalDistance = focalDistance
+	return c
 }
 
-func merge(cs ...<-chan Result) <-chan Result {
-	var wg sync.WaitGroup
-	out := make(chan Result)
+// SetApertureBlades gives the lens a polygonal aperture with the given number
+// of sides, shaping out of focus highlights.
+func (c *Camera) SetApertureBlades(n int) *Camera {
+	c.ApertureBlades = n
+	return c
+}
+
+func (c *Camera) SetIntegrator(i scene.Integrator) *Camera {
+	c.Integrator = i
+	return c
+}
+
+// cast computes the light arriving along the ray with the camera's
+// integrator.
+func (c *Camera) cast(s *scene.Scene, r ray.Ray) color.Color {
+	if c.Integrator != nil {
+		return c.Integrator.Radiance(s, r)
+	}
+	return scene.Whitted{}.Radiance(s, r)
+}
 
-	// Start an output goroutine for each input channel in cs.  output
-	// copies values from c to out until c is closed, then calls wg.Done.
-	output := func(c <-chan Result) {
-		for n := range c {
-			out <- n
+// SetToneMap sets how saved frames compress bright values into low dynamic
+// range image formats.
+func (c *Camera) SetToneMap(t canvas.ToneMap) *Camera {
+	c.ToneMap = t
+	return c
+}
+
+func (c *Camera) SetAntiAliasing(aa AntiAliasing) *Camera {
+	c.AntiAliasing = &aa
+	return c
+}
+
+// SetWorkers sets the number of goroutines rendering tiles. Zero uses one
+// per CPU.
+func (c *Camera) SetWorkers(n int) *Camera {
+	c.Workers = n
+	return c
+}
+
+// SetTiles sets the size of the tiles frames are split in, and the order in
+// which they are rendered.
+func (c *Camera) SetTiles(size int, order TileOrder) *Camera {
+	c.TileSize = size
+	c.TileOrder = order
+	return c
+}
+
+// Progress is told how many of the total pixels of a frame are rendered,
+// every time a tile completes. It is called from the rendering goroutine.
+type Progress func(rendered, total int)
+
+// TerminalProgress draws a progress bar for the render in the terminal.
+func TerminalProgress(name string) Progress {
+	var bar *uiprogress.Bar
+	return func(rendered, total int) {
+		if bar == nil {
+			uiprogress.Start()
+			bar = uiprogress.AddBar(total)
+			bar.AppendCompleted()
+			bar.PrependElapsed()
+			bar.PrependFunc(func(b *uiprogress.Bar) string {
+				return name
+			})
 		}
-		wg.Done()
+		bar.Set(rendered)
 	}
-	wg.Add(len(cs))
-	for _, c := range cs {
-		go output(c)
+}
+
+// renderTiles renders the scene into the frame with a pool of workers, each
+// writing the pixels of one tile at a time straight into the frame. The frame
+// must support concurrent writes to distinct pixels. Workers stop at the end
+// of the current row of pixels when the context is cancelled.
+func (c *Camera) renderTiles(ctx context.Context, s *scene.Scene, frame canvas.Canvas, progress Progress) error {
+	tiles := Tiles(frame.Width(), frame.Height(), c.TileSize, c.TileOrder)
+
+	workers := c.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	todo := make(chan Tile, len(tiles))
+	for _, t := range tiles {
+		todo <- t
 	}
+	close(todo)
 
-	// Start a goroutine to close out once all the output goroutines are
-	// done.  This must start after the wg.Add call.
+	done := make(chan Tile, len(tiles))
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for t := range todo {
+				for y := t.Y; y < t.Y+t.Height; y++ {
+					if ctx.Err() != nil {
+						return
+					}
+					for x := t.X; x < t.X+t.Width; x++ {
+						frame.Set(x, y, c.PixelColor(s, x, y))
+					}
+				}
+				done <- t
+			}
+		}()
+	}
 	go func() {
 		wg.Wait()
-		close(out)
+		close(done)
 	}()
-	return out
-}
-
-func RenderGoroutine(pxs <-chan canvas.Pixel, s *scene.Scene, c *Camera) <-chan Result {
-	out := make(chan Result)
-	go func() {
-		for px := range pxs {
-			x, y := px.X, px.Y
-
-			r := c.ProjectPixelRay(x, y)
-			color := s.Cast(r)
 
-			out <- Result{
-				Pixel: px,
-				Color: color,
-			}
+	rendered, total := 0, frame.Width()*frame.Height()
+	for t := range done {
+		rendered += t.Pixels()
+		if progress != nil {
+			progress(rendered, total)
 		}
-		close(out)
-	}()
-	return out
+	}
+	if rendered < total {
+		return ctx.Err()
+	}
+	return nil
 }
 
-const PARALLELISM = 4
-
-func (c *Camera) Render(s *scene.Scene, frame canvas.Canvas) {
+func (c *Camera) Render(s *scene.Scene, frame canvas.Canvas) error {
 	defer utils.TimeTrack(time.Now(), "Render")
-	pixels := frame.PixelChannel()
-	cs := []<-chan Result{}
+	return c.RenderContext(context.Background(), s, frame, nil)
+}
 
-	for x := 0; x < PARALLELISM; x++ {
-		cs = append(cs, RenderGoroutine(pixels, s, c))
+// RenderContext renders the scene into the frame until done or the context is
+// cancelled, in which case the frame is left partially rendered and the
+// context error is returned. Progress may be nil.
+func (c *Camera) RenderContext(ctx context.Context, s *scene.Scene, frame canvas.Canvas, progress Progress) error {
+	if err := c.Validate(); err != nil {
+		return err
 	}
-	for res := range merge(cs...) {
-		frame.Set(res.Pixel.X, res.Pixel.Y, res.Color)
+	built, err := s.Built()
+	if err != nil {
+		return err
 	}
+	return c.renderTiles(ctx, built, frame, progress)
 }
 
-func (c *Camera) SaveFrame(s *scene.Scene, filename string) {
+func (c *Camera) SaveFrame(s *scene.Scene, filename string) error {
 	defer utils.TimeTrack(time.Now(), "SaveFrame")
-	utils.EnsureDir(filepath.Dir(filename))
-	// Set up frame to render to
-	frame := canvas.NewImageCanvas(c.FrameWidth, c.FrameHeight)
-
-	// Set up progress bar
-	uiprogress.Start()
-	bar := uiprogress.AddBar(frame.Width() * frame.Height())
-	bar.AppendCompleted()
-	bar.PrependElapsed()
-	bar.PrependFunc(func(b *uiprogress.Bar) string {
-		return filepath.Base(filename)
-	})
-
-	pixels := frame.PixelChannel()
+	return c.SaveFrameContext(context.Background(), s, filename, TerminalProgress(filepath.Base(filename)))
+}
 
-	cs := []<-chan Result{}
-	for x := 0; x < PARALLELISM; x++ {
-		cs = append(cs, RenderGoroutine(pixels, s, c))
+// SaveFrameContext renders the scene and writes it to a file, in the image
+// format matching its extension. Nothing is written if the context is
+// cancelled. Progress may be nil.
+func (c *Camera) SaveFrameContext(ctx context.Context, s *scene.Scene, filename string, progress Progress) error {
+	if _, err := canvas.FormatOf(filename); err != nil {
+		return err
 	}
-	for res := range merge(cs...) {
-		frame.Set(res.Pixel.X, res.Pixel.Y, res.Color)
-		bar.Incr()
+	frame := canvas.NewImageCanvas(c.FrameWidth, c.FrameHeight)
+	if err := c.RenderContext(ctx, s, frame, progress); err != nil {
+		return err
+	}
+	return frame.WriteFile(filename, &canvas.Options{ToneMap: c.ToneMap})
+}
+
+// Validate checks that the camera can project rays.
+func (c *Camera) Validate() error {
+	if _, err := c.Transform.Invert(); err != nil {
+		return fmt.Errorf("camera transform: %w", err)
 	}
-	frame.WritePNG(filename)
+	return nil
 }
 
-func (c *Camera) LookAt(e interface{}) *Camera {
+// ErrInvalidTarget is returned when asking the camera to look at something
+// other than an entity or a point.
+var ErrInvalidTarget = errors.New("camera cannot look at target")
+
+// LookAt turns the camera towards an entity or a point.
+func (c *Camera) LookAt(e interface{}) (*Camera, error) {
 	var target math.Quaternion
 	switch t := e.(type) {
 	case core.Entity:
 		target = t.Transform().Apply(math.NewPoint(0, 0, 0))
 	case math.Quaternion:
-		if t.IsPoint() {
-			target = t
+		if !t.IsPoint() {
+			return c, fmt.Errorf("%w: %v is not a point", ErrInvalidTarget, e)
 		}
+		target = t
 	default:
-		panic(fmt.Errorf("Camera cannot look at %v", e))
+		return c, fmt.Errorf("%w: %v", ErrInvalidTarget, e)
 	}
 
 	self := c.Transform.Apply(math.NewPoint(0, 0, 0))
@@ -186,7 +309,7 @@ func (c *Camera) LookAt(e interface{}) *Camera {
 		target,
 		math.NewVector(0, 1, 0),
 	)
-	return c
+	return c, nil
 }
 
 func (c *Camera) MoveTo(p math.Point) *Camera {
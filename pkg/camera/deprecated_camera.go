@@ -51,7 +51,9 @@ func (v *Viewport) FrameXYToViewportXY(frame canvas.Canvas, fx int, fy int) (flo
 	return Vx, Vy
 }
 
-func (c *DeprecatedCamera) Render(canvas canvas.Canvas, scene []core.Entity, lights []core.Entity) {
+// Render shades the entities hit by the camera rays with every light. It
+// stops at the first shading error, leaving the canvas partially rendered.
+func (c *DeprecatedCamera) Render(canvas canvas.Canvas, scene []core.Entity, lights []core.Entity) error {
 
 	pixels := canvas.Pixels()
 	for pixels.More() {
@@ -74,18 +76,22 @@ func (c *DeprecatedCamera) Render(canvas canvas.Canvas, scene []core.Entity, lig
 				hitPoint := r.Position(hit.T)
 				pixelColor := color.New(0, 0, 0)
 				for _, l := range lights {
-					pixelColor = lighting.Phong(
+					c, err := lighting.Phong(
 						e.GetMaterial(),
 						l,
 						hitPoint,
 						r.Direction().Invert(),
 						e.Normal(hitPoint),
 					)
-
+					if err != nil {
+						return err
+					}
+					pixelColor = c
 				}
 				canvas.Set(x, y, pixelColor)
 			}
 		}
 
 	}
+	return nil
 }
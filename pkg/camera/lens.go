@@ -0,0 +1,49 @@
+package camera
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+)
+
+// lensRay fires a ray from a random point on the lens through the point of
+// the focal plane that the pinhole ray for the canvas point would reach.
+func (c *Camera) lensRay(worldX, worldY float64) ray.Ray {
+	scale := c.FocalDistance / c.Distance
+	focus := math.NewPoint(worldX*scale, worldY*scale, -c.FocalDistance)
+	lx, ly := c.sampleAperture()
+
+	inverse := c.Transform.Inverse()
+	target := inverse.Apply(focus)
+	origin := inverse.Apply(math.NewPoint(lx, ly, 0)).AsPoint()
+	direction := target.Sub(origin).AsVector().Normalize()
+	return ray.NewRay(
+		origin,
+		direction,
+	)
+}
+
+// sampleAperture picks a uniformly distributed point on the aperture, in
+// camera space.
+func (c *Camera) sampleAperture() (float64, float64) {
+	if c.ApertureBlades < 3 {
+		r := c.Aperture * m.Sqrt(rand.Float64())
+		theta := 2 * m.Pi * rand.Float64()
+		return r * m.Cos(theta), r * m.Sin(theta)
+	}
+
+	// Pick one of the triangles fanning out from the center of the polygon,
+	// then a point in it.
+	step := 2 * m.Pi / float64(c.ApertureBlades)
+	k := float64(rand.Intn(c.ApertureBlades))
+	ax, ay := m.Cos(k*step), m.Sin(k*step)
+	bx, by := m.Cos((k+1)*step), m.Sin((k+1)*step)
+
+	u, v := rand.Float64(), rand.Float64()
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	return c.Aperture * (u*ax + v*bx), c.Aperture * (u*ay + v*by)
+}
@@ -0,0 +1,55 @@
+package camera_test
+
+import (
+	m "math"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/camera"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+func TestLensRaysConvergeOnFocalPlane(t *testing.T) {
+	c := camera.CameraFromFOV(201, 101, halfPi).SetLens(0.5, 5)
+
+	for _, px := range [][2]int{{100, 50}, {0, 0}, {150, 20}} {
+		pinhole := camera.CameraFromFOV(201, 101, halfPi).ProjectPixelRay(px[0], px[1])
+		focus := pinhole.Position(-5 / pinhole.Direction().Z())
+
+		for i := 0; i < 100; i++ {
+			r := c.ProjectPixelRay(px[0], px[1])
+			o := r.Origin()
+			if o.Z() != 0 || m.Hypot(o.X(), o.Y()) > 0.5+utils.Epsilon {
+				t.Fatalf("Lens ray starts outside the lens: %v", r)
+			}
+			p := r.Position((-5 - o.Z()) / r.Direction().Z())
+			if !p.Equal(focus) {
+				t.Fatalf("Lens ray for pixel %v misses the focal point %v: %v", px, focus, p)
+			}
+		}
+	}
+}
+
+func TestLensRaysSpreadOverAperture(t *testing.T) {
+	c := camera.CameraFromFOV(11, 11, halfPi).SetLens(1, 5)
+
+	origins := map[math.Point]bool{}
+	for i := 0; i < 10; i++ {
+		origins[c.ProjectPixelRay(5, 5).Origin()] = true
+	}
+	if len(origins) < 2 {
+		t.Errorf("Expected lens rays to start from different points")
+	}
+}
+
+func TestPolygonalAperture(t *testing.T) {
+	c := camera.CameraFromFOV(11, 11, halfPi).SetLens(1, 5).SetApertureBlades(4)
+
+	for i := 0; i < 1000; i++ {
+		o := c.ProjectPixelRay(5, 5).Origin()
+		// Square aperture with corners on the axes
+		if m.Abs(o.X())+m.Abs(o.Y()) > 1+utils.Epsilon {
+			t.Fatalf("Lens ray starts outside of square aperture: %v", o)
+		}
+	}
+}
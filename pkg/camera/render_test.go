@@ -0,0 +1,178 @@
+package camera_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/camera"
+	"github.com/bricef/ray-tracer/pkg/canvas"
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+func TestRenderReportsProgress(t *testing.T) {
+	c := camera.CameraFromFOV(20, 10, halfPi).SetTiles(4, camera.Scanline)
+	frame := canvas.NewImageCanvas(20, 10)
+
+	last := 0
+	err := c.RenderContext(context.Background(), scene.DefaultScene(), frame, func(rendered, total int) {
+		if total != 200 {
+			t.Errorf("Expected 200 pixels in total. Got %v", total)
+		}
+		if rendered <= last {
+			t.Errorf("Progress went from %v to %v", last, rendered)
+		}
+		last = rendered
+	})
+	if err != nil {
+		t.Errorf("Unexpected render error: %v", err)
+	}
+	if last != 200 {
+		t.Errorf("Expected progress to reach all pixels. Got %v", last)
+	}
+}
+
+func TestRenderStopsWhenCancelled(t *testing.T) {
+	c := camera.CameraFromFOV(20, 10, halfPi).SetWorkers(1).SetTiles(4, camera.Scanline)
+	frame := canvas.NewImageCanvas(20, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	last := 0
+	err := c.RenderContext(ctx, scene.DefaultScene(), frame, func(rendered, total int) {
+		last = rendered
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancelled render to fail with %v. Got %v", context.Canceled, err)
+	}
+	if last >= 200 {
+		t.Errorf("Expected render to stop early. Rendered %v pixels", last)
+	}
+}
+
+func TestSaveFrameWritesNothingWhenCancelled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camera")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "frame.png")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := camera.CameraFromFOV(20, 10, halfPi)
+	if err := c.SaveFrameContext(ctx, scene.DefaultScene(), filename, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancelled save to fail with %v. Got %v", context.Canceled, err)
+	}
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		t.Errorf("Expected no file written for a cancelled render")
+	}
+
+	if err := c.SaveFrameContext(context.Background(), scene.DefaultScene(), filename, nil); err != nil {
+		t.Errorf("Unexpected error saving frame: %v", err)
+	}
+	if _, err := os.Stat(filename); err != nil {
+		t.Errorf("Expected frame to be written: %v", err)
+	}
+}
+
+func TestConcurrentRendersOfOneScene(t *testing.T) {
+	s := scene.DefaultScene()
+	c := camera.CameraFromFOV(20, 10, halfPi)
+	expected := canvas.NewImageCanvas(20, 10)
+	if err := c.Render(s, expected); err != nil {
+		t.Fatalf("Unexpected render error: %v", err)
+	}
+
+	frames := []*canvas.ImageCanvas{canvas.NewImageCanvas(20, 10), canvas.NewImageCanvas(20, 10)}
+	errs := make(chan error, len(frames))
+	for _, frame := range frames {
+		go func(frame *canvas.ImageCanvas) {
+			errs <- c.Render(s, frame)
+		}(frame)
+	}
+	for range frames {
+		if err := <-errs; err != nil {
+			t.Fatalf("Unexpected render error: %v", err)
+		}
+	}
+	for _, frame := range frames {
+		for x := 0; x < 20; x++ {
+			for y := 0; y < 10; y++ {
+				a, _ := frame.Get(x, y)
+				b, _ := expected.Get(x, y)
+				if a != b {
+					t.Fatalf("Concurrent render differs at %v,%v. Expected %v, got %v", x, y, b, a)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderRejectsNonInvertibleTransforms(t *testing.T) {
+	s := scene.DefaultScene()
+	s.Add(entities.NewGroup().AddChild(entities.NewSphere().Scale(1, 0, 1)))
+	c := camera.CameraFromFOV(20, 10, halfPi)
+
+	var ierr *math.NonInvertibleError
+	if err := c.Render(s, canvas.NewImageCanvas(20, 10)); !errors.As(err, &ierr) {
+		t.Errorf("Expected a non invertible error rendering a flattened sphere. Got %v", err)
+	}
+}
+
+func TestDeprecatedRenderReportsShadingErrors(t *testing.T) {
+	c := camera.NewDeprecatedCamera(
+		math.NewPoint(0, 0, 5),
+		math.NewVector(0, 0, -1),
+		1,
+		camera.NewViewport(1, 1),
+	)
+	// A sphere is not a light
+	lights := []core.Entity{entities.NewSphere()}
+	err := c.Render(canvas.NewImageCanvas(4, 4), []core.Entity{entities.NewSphere()}, lights)
+	if !errors.Is(err, lighting.ErrNoLight) {
+		t.Errorf("Expected shading with a non light entity to fail. Got %v", err)
+	}
+}
+
+func TestLookAtInvalidTarget(t *testing.T) {
+	c := camera.CameraFromFOV(20, 10, halfPi)
+	if _, err := c.LookAt(math.NewVector(0, 0, 1)); !errors.Is(err, camera.ErrInvalidTarget) {
+		t.Errorf("Expected looking at a vector to fail. Got %v", err)
+	}
+	if _, err := c.LookAt("teapot"); !errors.Is(err, camera.ErrInvalidTarget) {
+		t.Errorf("Expected looking at a string to fail. Got %v", err)
+	}
+	if _, err := c.MoveTo(math.NewPoint(0, 0, -5)).LookAt(math.NewPoint(0, 0, 0)); err != nil {
+		t.Errorf("Unexpected error looking at a point: %v", err)
+	}
+}
+
+func TestPathTracingIntegrator(t *testing.T) {
+	// White furnace: the path tracer sees a white sphere under a white sky as
+	// white, while the Whitted tracer only has its direct light.
+	s := scene.NewScene()
+	s.BackgroundColor = color.White
+	s.Add(entities.NewSphere().AddComponent(
+		material.NewMaterial().SetDiffuse(1).SetSpecular(0),
+	))
+	c := camera.CameraFromFOV(5, 5, 0.3)
+	c.SetTransform(math.ViewTransform(math.NewPoint(0, 0, -5), math.NewPoint(0, 0, 0), math.NewVector(0, 1, 0)))
+
+	if p := c.PixelColor(s, 2, 2); p.Equal(color.White) {
+		t.Errorf("Expected the Whitted tracer to miss indirect light. Got %v", p)
+	}
+	c.SetIntegrator(scene.PathTracer{})
+	if p := c.PixelColor(s, 2, 2); !p.Equal(color.White) {
+		t.Errorf("Expected the path tracer to pick up light from the sky. Got %v", p)
+	}
+}
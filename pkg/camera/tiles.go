@@ -0,0 +1,119 @@
+package camera
+
+import (
+	m "math"
+	"sort"
+)
+
+// Default edge length of a tile, in pixels
+const DefaultTileSize = 16
+
+// TileOrder decides in which order the tiles of a frame are rendered.
+type TileOrder int
+
+const (
+	// Scanline renders rows of tiles from the top left.
+	Scanline TileOrder = iota
+	// Spiral renders from the center of the frame outwards.
+	Spiral
+	// Hilbert follows a Hilbert curve, keeping consecutive tiles adjacent.
+	Hilbert
+)
+
+// Tile is a rectangle of pixels rendered by a single worker.
+type Tile struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Pixels is the number of pixels in the tile.
+func (t Tile) Pixels() int {
+	return t.Width * t.Height
+}
+
+// Tiles splits a frame in tiles of at most size x size pixels, in the given
+// order.
+func Tiles(width, height, size int, order TileOrder) []Tile {
+	if size <= 0 {
+		size = DefaultTileSize
+	}
+	nx := (width + size - 1) / size
+	ny := (height + size - 1) / size
+
+	type cell struct {
+		i, j int
+		tile Tile
+	}
+	cells := make([]cell, 0, nx*ny)
+	for j := 0; j < ny; j++ {
+		for i := 0; i < nx; i++ {
+			x, y := i*size, j*size
+			w, h := size, size
+			if x+w > width {
+				w = width - x
+			}
+			if y+h > height {
+				h = height - y
+			}
+			cells = append(cells, cell{i, j, Tile{x, y, w, h}})
+		}
+	}
+
+	switch order {
+	case Spiral:
+		cx, cy := float64(nx-1)/2, float64(ny-1)/2
+		ring := func(c cell) float64 {
+			return m.Max(m.Abs(float64(c.i)-cx), m.Abs(float64(c.j)-cy))
+		}
+		angle := func(c cell) float64 {
+			return m.Atan2(float64(c.j)-cy, float64(c.i)-cx)
+		}
+		sort.SliceStable(cells, func(a, b int) bool {
+			ra, rb := ring(cells[a]), ring(cells[b])
+			if ra != rb {
+				return ra < rb
+			}
+			return angle(cells[a]) < angle(cells[b])
+		})
+	case Hilbert:
+		n := 1
+		for n < nx || n < ny {
+			n *= 2
+		}
+		sort.SliceStable(cells, func(a, b int) bool {
+			return hilbertIndex(n, cells[a].i, cells[a].j) < hilbertIndex(n, cells[b].i, cells[b].j)
+		})
+	}
+
+	tiles := make([]Tile, len(cells))
+	for i, c := range cells {
+		tiles[i] = c.tile
+	}
+	return tiles
+}
+
+// hilbertIndex is the distance along the Hilbert curve filling an n x n grid,
+// n being a power of two, to the cell (x, y).
+func hilbertIndex(n, x, y int) int {
+	d := 0
+	for s := n / 2; s > 0; s /= 2 {
+		rx, ry := 0, 0
+		if x&s > 0 {
+			rx = 1
+		}
+		if y&s > 0 {
+			ry = 1
+		}
+		d += s * s * ((3 * rx) ^ ry)
+		if ry == 0 {
+			if rx == 1 {
+				x = n - 1 - x
+				y = n - 1 - y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+package camera_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/camera"
+	"github.com/bricef/ray-tracer/pkg/canvas"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+var orders = []camera.TileOrder{camera.Scanline, camera.Spiral, camera.Hilbert}
+
+func TestTilesCoverFrameOnce(t *testing.T) {
+	for _, order := range orders {
+		covered := make([][]int, 37)
+		for x := range covered {
+			covered[x] = make([]int, 23)
+		}
+		for _, tile := range camera.Tiles(37, 23, 8, order) {
+			for x := tile.X; x < tile.X+tile.Width; x++ {
+				for y := tile.Y; y < tile.Y+tile.Height; y++ {
+					covered[x][y]++
+				}
+			}
+		}
+		for x := range covered {
+			for y, n := range covered[x] {
+				if n != 1 {
+					t.Fatalf("Pixel %v,%v covered %v times by tiles in order %v", x, y, n, order)
+				}
+			}
+		}
+	}
+}
+
+func TestHilbertTilesAreAdjacent(t *testing.T) {
+	tiles := camera.Tiles(64, 64, 16, camera.Hilbert)
+	for i := 1; i < len(tiles); i++ {
+		dx := tiles[i].X - tiles[i-1].X
+		dy := tiles[i].Y - tiles[i-1].Y
+		if dx*dx+dy*dy != 16*16 {
+			t.Errorf("Hilbert tile %v at %v,%v is not next to the previous tile at %v,%v", i, tiles[i].X, tiles[i].Y, tiles[i-1].X, tiles[i-1].Y)
+		}
+	}
+}
+
+func TestSpiralTilesStartInCenter(t *testing.T) {
+	tiles := camera.Tiles(80, 48, 16, camera.Spiral)
+	if tiles[0].X != 32 || tiles[0].Y != 16 {
+		t.Errorf("Expected spiral to start with the center tile. Got %v", tiles[0])
+	}
+}
+
+func TestTiledRenderMatchesPixelByPixel(t *testing.T) {
+	s := scene.DefaultScene()
+	c := camera.CameraFromFOV(21, 13, halfPi)
+	c.SetTransform(
+		math.ViewTransform(
+			math.NewPoint(0, 0, -5),
+			math.NewPoint(0, 0, 0),
+			math.NewVector(0, 1, 0),
+		),
+	)
+	s.Build()
+	expected := canvas.NewImageCanvas(21, 13)
+	for x := 0; x < 21; x++ {
+		for y := 0; y < 13; y++ {
+			expected.Set(x, y, c.PixelColor(s, x, y))
+		}
+	}
+
+	for _, order := range orders {
+		for _, workers := range []int{0, 1, 3} {
+			frame := canvas.NewImageCanvas(21, 13)
+			c.SetWorkers(workers).SetTiles(4, order)
+			if err := c.Render(s, frame); err != nil {
+				t.Fatal(err)
+			}
+			for x := 0; x < 21; x++ {
+				for y := 0; y < 13; y++ {
+					a, _ := frame.Get(x, y)
+					b, _ := expected.Get(x, y)
+					if a != b {
+						t.Fatalf("Tiled render differs at %v,%v with order %v and %v workers. Expected %v, got %v", x, y, order, workers, b, a)
+					}
+				}
+			}
+		}
+	}
+}
@@ -4,14 +4,9 @@ import (
 	"fmt"
 	"image"
 	imageColor "image/color"
-	"image/png"
-	"log"
 	"math"
-	"os"
-	"path/filepath"
 
 	"github.com/bricef/ray-tracer/pkg/color"
-	"github.com/bricef/ray-tracer/pkg/utils"
 )
 
 type Pixel struct {
@@ -111,11 +106,16 @@ func (c *ImageCanvas) Get(x, y int) (color.Color, error) {
 	return c.pixels[x][y], nil
 }
 
+// Image converts the canvas to an sRGB image, clamping values to [0, 1].
 func (c *ImageCanvas) Image() image.Image {
+	return c.image(ToneMap{})
+}
+
+func (c *ImageCanvas) image(t ToneMap) image.Image {
 	img := image.NewNRGBA64(image.Rect(0, 0, c.width, c.height))
 	for x, column := range c.pixels {
 		for y, pixel := range column {
-			pixel = pixel.Cutoff()
+			pixel = t.Apply(pixel)
 			img.Set(x, y, imageColor.RGBA64{
 				uint16(pixel.R * math.MaxUint16),
 				uint16(pixel.G * math.MaxUint16),
@@ -128,15 +128,7 @@ func (c *ImageCanvas) Image() image.Image {
 	return img
 }
 
-func (c *ImageCanvas) WritePNG(filename string) {
-	utils.EnsureDir(filepath.Dir(filename))
-	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0755)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	err = png.Encode(f, c.Image())
-	if err != nil {
-		log.Fatal(err)
-	}
+// WritePNG writes the canvas as a PNG file, whatever the file extension.
+func (c *ImageCanvas) WritePNG(filename string) error {
+	return c.write(filename, PNG, nil)
 }
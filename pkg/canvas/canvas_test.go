@@ -1,6 +1,13 @@
 package canvas
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bricef/ray-tracer/pkg/color"
@@ -43,3 +50,170 @@ func TestCanvasPixelIteratorIteratesOverAllPixels(t *testing.T) {
 		t.Errorf("Pixel iterator returned wrong number of pixels. Expected %v got %v.", expected, count)
 	}
 }
+
+func TestWritePNGReportsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "canvas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := NewImageCanvas(2, 2)
+	filename := filepath.Join(dir, "frame.png")
+	if err := c.WritePNG(filename); err != nil {
+		t.Errorf("Failed to write PNG: %v", err)
+	}
+	if err := c.WritePNG(filepath.Join(filename, "nested.png")); err == nil {
+		t.Errorf("Expected an error writing under a file")
+	}
+}
+
+func TestPPMHeader(t *testing.T) {
+	var b bytes.Buffer
+	NewImageCanvas(5, 3).EncodePPM(&b)
+	lines := strings.Split(b.String(), "\n")
+	if header := strings.Join(lines[:3], "\n"); header != "P3\n5 3\n255" {
+		t.Errorf("Wrong PPM header. Got %q", header)
+	}
+}
+
+func TestPPMPixelData(t *testing.T) {
+	c := NewImageCanvas(5, 3)
+	c.Set(0, 0, color.New(1.5, 0, 0))
+	c.Set(2, 1, color.New(0, 0.5, 0))
+	c.Set(4, 2, color.New(-0.5, 0, 1))
+
+	var b bytes.Buffer
+	c.EncodePPM(&b)
+	lines := strings.Split(b.String(), "\n")
+	expected := []string{
+		"255 0 0 0 0 0 0 0 0 0 0 0 0 0 0",
+		"0 0 0 0 0 0 0 128 0 0 0 0 0 0 0",
+		"0 0 0 0 0 0 0 0 0 0 0 0 0 0 255",
+	}
+	for i, line := range expected {
+		if lines[3+i] != line {
+			t.Errorf("Wrong PPM pixel data on line %v. Expected %q, got %q", 4+i, line, lines[3+i])
+		}
+	}
+}
+
+func TestPPMSplitsLongLines(t *testing.T) {
+	c := NewImageCanvas(10, 2)
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 2; y++ {
+			c.Set(x, y, color.New(1, 0.8, 0.6))
+		}
+	}
+
+	var b bytes.Buffer
+	c.EncodePPM(&b)
+	lines := strings.Split(b.String(), "\n")
+	expected := []string{
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204",
+		"153 255 204 153 255 204 153 255 204 153 255 204 153",
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204",
+		"153 255 204 153 255 204 153 255 204 153 255 204 153",
+	}
+	for i, line := range expected {
+		if lines[3+i] != line {
+			t.Errorf("Wrong PPM line %v. Expected %q, got %q", 4+i, line, lines[3+i])
+		}
+	}
+	if !strings.HasSuffix(b.String(), "\n") {
+		t.Errorf("Expected PPM data to end with a newline")
+	}
+}
+
+func TestBinaryPPM(t *testing.T) {
+	c := NewImageCanvas(2, 1)
+	c.Set(1, 0, color.New(1, 0.5, 0))
+
+	var b bytes.Buffer
+	c.EncodePPMBinary(&b)
+	expected := append([]byte("P6\n2 1\n255\n"), 0, 0, 0, 255, 128, 0)
+	if !bytes.Equal(b.Bytes(), expected) {
+		t.Errorf("Wrong binary PPM. Expected %v, got %v", expected, b.Bytes())
+	}
+}
+
+func TestPFMKeepsUnclampedValues(t *testing.T) {
+	c := NewImageCanvas(1, 2)
+	c.Set(0, 0, color.New(1.5, -0.5, 100))
+
+	var b bytes.Buffer
+	c.EncodePFM(&b)
+	header := "PF\n1 2\n-1.0\n"
+	if !strings.HasPrefix(b.String(), header) {
+		t.Fatalf("Wrong PFM header. Got %q", b.String())
+	}
+	var pixels [2][3]float32
+	if err := binary.Read(bytes.NewReader(b.Bytes()[len(header):]), binary.LittleEndian, &pixels); err != nil {
+		t.Fatal(err)
+	}
+	// Rows are stored bottom to top
+	if pixels[1] != [3]float32{1.5, -0.5, 100} || pixels[0] != [3]float32{} {
+		t.Errorf("Wrong PFM pixel data. Got %v", pixels)
+	}
+}
+
+func TestHDRKeepsUnclampedValues(t *testing.T) {
+	c := NewImageCanvas(1, 1)
+	c.Set(0, 0, color.New(4, 2, 1))
+
+	var b bytes.Buffer
+	c.EncodeHDR(&b)
+	header := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 1\n"
+	if !strings.HasPrefix(b.String(), header) {
+		t.Fatalf("Wrong HDR header. Got %q", b.String())
+	}
+	// 4 = 0.5 * 2^3, so the exponent byte is 128 + 3
+	expected := []byte{128, 64, 32, 131}
+	if got := b.Bytes()[len(header):]; !bytes.Equal(got, expected) {
+		t.Errorf("Wrong RGBE pixel. Expected %v, got %v", expected, got)
+	}
+}
+
+func TestHDRRunLengthScanlines(t *testing.T) {
+	c := NewImageCanvas(200, 1)
+	c.Set(150, 0, color.New(1, 1, 1))
+
+	var b bytes.Buffer
+	c.EncodeHDR(&b)
+	data := b.Bytes()[len("#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 200\n"):]
+	if !bytes.Equal(data[:4], []byte{2, 2, 0, 200}) {
+		t.Errorf("Wrong scanline header. Got %v", data[:4])
+	}
+	// Each of the four channels is two literal chunks of 128 and 72 values
+	if len(data) != 4+4*(2+200) {
+		t.Errorf("Wrong scanline length. Got %v", len(data))
+	}
+	red := data[4 : 4+2+200]
+	if red[0] != 128 || red[1+128] != 72 || red[2+150] != 128 {
+		t.Errorf("Wrong red channel. Got %v", red)
+	}
+}
+
+func TestWriteFileByExtension(t *testing.T) {
+	dir, err := ioutil.TempDir("", "canvas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := NewImageCanvas(4, 4)
+	c.Set(1, 1, color.New(2, 0.5, 0))
+	for _, name := range []string{"a.png", "a.ppm", "a.jpg", "a.JPEG", "a.pfm", "a.hdr"} {
+		filename := filepath.Join(dir, name)
+		if err := c.WriteFile(filename, &Options{Quality: 50}); err != nil {
+			t.Errorf("Unexpected error writing %v: %v", name, err)
+		}
+		if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+			t.Errorf("Expected %v to be written", name)
+		}
+	}
+
+	if err := c.WriteFile(filepath.Join(dir, "a.gif"), nil); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected unknown extension to fail with %v. Got %v", ErrUnknownFormat, err)
+	}
+}
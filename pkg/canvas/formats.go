@@ -0,0 +1,232 @@
+package canvas
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"io"
+	m "math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+// Format is an image file format canvases can be written in.
+type Format int
+
+const (
+	PNG Format = iota
+	// Portable pixmap, binary (P6) unless Options.PlainPPM is set (P3)
+	PPM
+	JPEG
+	// Portable float map, keeping unclamped 32 bit floats
+	PFM
+	// Radiance RGBE, keeping unclamped values with a shared exponent
+	HDR
+)
+
+// ErrUnknownFormat is returned for file extensions without an encoder.
+var ErrUnknownFormat = errors.New("unknown image format")
+
+// Options tune the encoding of images. A nil *Options uses the defaults.
+type Options struct {
+	// JPEG quality, from 1 to 100. Zero uses jpeg.DefaultQuality.
+	Quality int
+	// Write PPM files as plain text (P3) instead of binary (P6)
+	PlainPPM bool
+	// Tone mapping of low dynamic range formats. High dynamic range formats
+	// keep the canvas values as they are.
+	ToneMap ToneMap
+}
+
+// FormatOf picks the image format from the file extension.
+func FormatOf(filename string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".png":
+		return PNG, nil
+	case ".ppm":
+		return PPM, nil
+	case ".jpg", ".jpeg":
+		return JPEG, nil
+	case ".pfm":
+		return PFM, nil
+	case ".hdr":
+		return HDR, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownFormat, filename)
+}
+
+// WriteFile writes the canvas in the format matching the file extension.
+func (c *ImageCanvas) WriteFile(filename string, o *Options) error {
+	f, err := FormatOf(filename)
+	if err != nil {
+		return err
+	}
+	return c.write(filename, f, o)
+}
+
+func (c *ImageCanvas) write(filename string, f Format, o *Options) error {
+	if err := utils.EnsureDir(filepath.Dir(filename)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = c.Encode(file, f, o)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Encode writes the canvas to w in the given format.
+func (c *ImageCanvas) Encode(w io.Writer, f Format, o *Options) error {
+	if o == nil {
+		o = &Options{}
+	}
+	switch f {
+	case PNG:
+		return png.Encode(w, c.image(o.ToneMap))
+	case PPM:
+		c = c.ToneMapped(o.ToneMap)
+		if o.PlainPPM {
+			return c.EncodePPM(w)
+		}
+		return c.EncodePPMBinary(w)
+	case JPEG:
+		quality := o.Quality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+		return jpeg.Encode(w, c.image(o.ToneMap), &jpeg.Options{Quality: quality})
+	case PFM:
+		return c.EncodePFM(w)
+	case HDR:
+		return c.EncodeHDR(w)
+	}
+	return fmt.Errorf("%w: %v", ErrUnknownFormat, f)
+}
+
+// byteValue clamps a channel to [0, 1] and scales it to [0, 255].
+func byteValue(v float64) byte {
+	return byte(m.Round(m.Max(0, m.Min(1, v)) * 255))
+}
+
+// EncodePPM writes the canvas as a plain text (P3) PPM file, with lines no
+// longer than 70 characters. Values are written as they are, only clamped, so
+// the canvas should be tone mapped first. Encode does so for PPM files.
+func (c *ImageCanvas) EncodePPM(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "P3\n%v %v\n255\n", c.width, c.height)
+	for y := 0; y < c.height; y++ {
+		line := 0
+		for x := 0; x < c.width; x++ {
+			p := c.pixels[x][y]
+			for _, v := range []float64{p.R, p.G, p.B} {
+				s := strconv.Itoa(int(byteValue(v)))
+				if line > 0 && line+1+len(s) > 70 {
+					b.WriteString("\n")
+					line = 0
+				}
+				if line > 0 {
+					b.WriteString(" ")
+					line++
+				}
+				b.WriteString(s)
+				line += len(s)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.Flush()
+}
+
+// EncodePPMBinary writes the canvas as a binary (P6) PPM file. Values are
+// written as they are, only clamped, so the canvas should be tone mapped
+// first. Encode does so for PPM files.
+func (c *ImageCanvas) EncodePPMBinary(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "P6\n%v %v\n255\n", c.width, c.height)
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			p := c.pixels[x][y]
+			b.Write([]byte{byteValue(p.R), byteValue(p.G), byteValue(p.B)})
+		}
+	}
+	return b.Flush()
+}
+
+// EncodePFM writes the canvas as a little endian portable float map. PFM rows
+// go from the bottom of the image to the top.
+func (c *ImageCanvas) EncodePFM(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "PF\n%v %v\n-1.0\n", c.width, c.height)
+	for y := c.height - 1; y >= 0; y-- {
+		for x := 0; x < c.width; x++ {
+			p := c.pixels[x][y]
+			err := binary.Write(b, binary.LittleEndian, [3]float32{float32(p.R), float32(p.G), float32(p.B)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return b.Flush()
+}
+
+// rgbe packs a color in the shared exponent format of Radiance files.
+// Negative channels cannot be represented and are clamped to 0.
+func rgbe(c color.Color) [4]byte {
+	r, g, b := m.Max(0, c.R), m.Max(0, c.G), m.Max(0, c.B)
+	v := m.Max(r, m.Max(g, b))
+	if v < 1e-32 {
+		return [4]byte{}
+	}
+	mantissa, exp := m.Frexp(v)
+	scale := mantissa * 256 / v
+	return [4]byte{byte(r * scale), byte(g * scale), byte(b * scale), byte(exp + 128)}
+}
+
+// EncodeHDR writes the canvas as a Radiance RGBE file.
+func (c *ImageCanvas) EncodeHDR(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %v +X %v\n", c.height, c.width)
+
+	scanline := make([][4]byte, c.width)
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			scanline[x] = rgbe(c.pixels[x][y])
+		}
+		// Readers only accept flat scanlines outside of these widths. Within
+		// them, a flat scanline could be mistaken for a run length encoded
+		// one, so write each channel as uncompressed chunks instead.
+		if c.width < 8 || c.width > 0x7fff {
+			for _, p := range scanline {
+				b.Write(p[:])
+			}
+			continue
+		}
+		b.Write([]byte{2, 2, byte(c.width >> 8), byte(c.width & 0xff)})
+		for channel := 0; channel < 4; channel++ {
+			for start := 0; start < c.width; start += 128 {
+				end := start + 128
+				if end > c.width {
+					end = c.width
+				}
+				b.WriteByte(byte(end - start))
+				for _, p := range scanline[start:end] {
+					b.WriteByte(p[channel])
+				}
+			}
+		}
+	}
+	return b.Flush()
+}
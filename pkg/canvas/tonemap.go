@@ -0,0 +1,88 @@
+package canvas
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+)
+
+// ToneOperator is the curve compressing unbounded radiance into the [0, 1]
+// range of low dynamic range images.
+type ToneOperator int
+
+const (
+	// Clamp cuts off values outside of [0, 1], blowing highlights out to
+	// white.
+	Clamp ToneOperator = iota
+	// Reinhard maps v to v / (1 + v), extended to reach white at ToneMap.White.
+	Reinhard
+	// Filmic is John Hable's curve from Uncharted 2, with a toe lifting the
+	// shadows and a soft shoulder on highlights.
+	Filmic
+	// ACES is Krzysztof Narkowicz's fit of the ACES reference rendering
+	// transform.
+	ACES
+)
+
+// ToneMap configures how a canvas is mapped to a low dynamic range image.
+// The zero value clamps values and encodes them as sRGB.
+type ToneMap struct {
+	Operator ToneOperator
+	// Exposure in stops, applied before the curve. Each stop doubles the
+	// brightness.
+	Exposure float64
+	// Gamma encoding applied after the curve. Zero uses the sRGB transfer
+	// function expected by image viewers, one leaves values linear.
+	Gamma float64
+	// White point of the Reinhard and Filmic curves. Zero uses the plain
+	// Reinhard curve and Filmic's default of 11.2.
+	White float64
+}
+
+// hable is the filmic curve before normalisation to the white point.
+func hable(x float64) float64 {
+	const a, b, c, d, e, f = 0.15, 0.50, 0.10, 0.20, 0.02, 0.30
+	return ((x*(a*x+c*b) + d*e) / (x*(a*x+b) + d*f)) - e/f
+}
+
+func (t ToneMap) channel(v float64) float64 {
+	v = m.Max(0, v*m.Exp2(t.Exposure))
+	switch t.Operator {
+	case Reinhard:
+		if t.White > 0 {
+			v = v * (1 + v/(t.White*t.White)) / (1 + v)
+		} else {
+			v = v / (1 + v)
+		}
+	case Filmic:
+		white := t.White
+		if white <= 0 {
+			white = 11.2
+		}
+		// Hable's exposure bias, so that mid grey stays about the same
+		v = hable(2*v) / hable(white)
+	case ACES:
+		v = (v * (2.51*v + 0.03)) / (v*(2.43*v+0.59) + 0.14)
+	}
+	v = m.Min(1, v)
+	if t.Gamma > 0 {
+		return m.Pow(v, 1/t.Gamma)
+	}
+	return color.EncodeSRGB(v)
+}
+
+// Apply maps a color to the [0, 1] range.
+func (t ToneMap) Apply(c color.Color) color.Color {
+	return color.New(t.channel(c.R), t.channel(c.G), t.channel(c.B))
+}
+
+// ToneMapped returns a copy of the canvas with every pixel tone mapped.
+func (c *ImageCanvas) ToneMapped(t ToneMap) *ImageCanvas {
+	out := NewImageCanvas(c.width, c.height)
+	for x, column := range c.pixels {
+		for y, pixel := range column {
+			out.pixels[x][y] = t.Apply(pixel)
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+func TestDefaultToneMapClamps(t *testing.T) {
+	got := ToneMap{}.Apply(color.New(1.5, -0.5, 0.25))
+	if !got.Equal(color.New(1, 0, color.EncodeSRGB(0.25))) {
+		t.Errorf("Expected values clamped to [0, 1] and sRGB encoded. Got %v", got)
+	}
+}
+
+func TestExposureAndGamma(t *testing.T) {
+	got := ToneMap{Exposure: 1, Gamma: 2}.Apply(color.New(0.125, 0.5, 1))
+	if !got.Equal(color.New(0.5, 1, 1)) {
+		t.Errorf("Expected exposure to double values before gamma. Got %v", got)
+	}
+}
+
+func TestReinhard(t *testing.T) {
+	got := ToneMap{Operator: Reinhard, Gamma: 1}.Apply(color.New(1, 3, 0))
+	if !got.Equal(color.New(0.5, 0.75, 0)) {
+		t.Errorf("Wrong Reinhard mapping. Got %v", got)
+	}
+	got = ToneMap{Operator: Reinhard, White: 4, Gamma: 1}.Apply(color.New(4, 8, 0))
+	if !got.Equal(color.New(1, 1, 0)) {
+		t.Errorf("Expected extended Reinhard to reach white at the white point. Got %v", got)
+	}
+}
+
+func TestCurvesKeepHighlightDetail(t *testing.T) {
+	for _, op := range []ToneOperator{Reinhard, Filmic, ACES} {
+		tm := ToneMap{Operator: op}
+		previous := -1.0
+		for _, v := range []float64{0, 0.1, 0.5, 1, 2, 4, 8} {
+			got := tm.Apply(color.New(v, v, v)).R
+			if got < 0 || got > 1 {
+				t.Errorf("Operator %v maps %v out of range to %v", op, v, got)
+			}
+			if got <= previous {
+				t.Errorf("Operator %v maps %v to %v, no brighter than a dimmer value", op, v, got)
+			}
+			previous = got
+		}
+		if black := tm.Apply(color.Black); !black.Equal(color.Black) {
+			t.Errorf("Operator %v doesn't keep black. Got %v", op, black)
+		}
+	}
+}
+
+func TestToneMappedEncoding(t *testing.T) {
+	c := NewImageCanvas(1, 1)
+	c.Set(0, 0, color.New(3, 1, 0))
+
+	var b bytes.Buffer
+	c.Encode(&b, PPM, &Options{PlainPPM: true, ToneMap: ToneMap{Operator: Reinhard, Gamma: 1}})
+	if got := strings.Split(b.String(), "\n")[3]; got != "191 128 0" {
+		t.Errorf("Expected tone mapped PPM pixel. Got %q", got)
+	}
+
+	b.Reset()
+	c.Encode(&b, PFM, &Options{ToneMap: ToneMap{Operator: Reinhard}})
+	var pixel [3]float32
+	binary.Read(bytes.NewReader(b.Bytes()[len("PF\n1 1\n-1.0\n"):]), binary.LittleEndian, &pixel)
+	if pixel != [3]float32{3, 1, 0} {
+		t.Errorf("Expected PFM to ignore tone mapping. Got %v", pixel)
+	}
+}
+
+func TestPNGIsSRGBEncoded(t *testing.T) {
+	c := NewImageCanvas(1, 1)
+	c.Set(0, 0, color.New(0.5, 0.5, 0.5))
+
+	var b bytes.Buffer
+	if err := c.Encode(&b, PNG, nil); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _, _, _ := img.At(0, 0).RGBA()
+	if v := float64(r) / 0xffff; !utils.EqualToTolerance(v, 0.735, 0.001) {
+		t.Errorf("Expected linear mid grey to be written as sRGB 0.735. Got %v", v)
+	}
+}
@@ -17,6 +17,8 @@ func New(r, g, b float64) Color {
 	return Color{r, g, b}
 }
 
+// Hex decodes a 24 bit sRGB color, as given by colour pickers, to linear
+// values.
 func Hex(v uint32) Color {
 	r := uint16(v & 0xff0000 >> 16)
 	g := uint16(v & 0x00ff00 >> 8)
@@ -24,12 +26,41 @@ func Hex(v uint32) Color {
 	return Bytes(r, g, b)
 }
 
+// Bytes decodes 8 bit sRGB channels to linear values.
 func Bytes(rb, gb, bb uint16) Color {
 	max := float64(255)
 	r := float64(rb) / max
 	g := float64(gb) / max
 	b := float64(bb) / max
-	return New(r, g, b)
+	return SRGB(r, g, b)
+}
+
+// SRGB decodes sRGB channels in [0, 1] to the linear values shading works
+// with.
+func SRGB(r, g, b float64) Color {
+	return New(DecodeSRGB(r), DecodeSRGB(g), DecodeSRGB(b))
+}
+
+// DecodeSRGB applies the inverse of the sRGB transfer function to a channel.
+func DecodeSRGB(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// EncodeSRGB applies the sRGB transfer function to a linear channel in
+// [0, 1], for display.
+func EncodeSRGB(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// ToSRGB encodes linear channels in [0, 1] as sRGB.
+func (c Color) ToSRGB() Color {
+	return Color{EncodeSRGB(c.R), EncodeSRGB(c.G), EncodeSRGB(c.B)}
 }
 
 func (c Color) Add(o Color) Color {
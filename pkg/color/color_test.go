@@ -1,6 +1,10 @@
 package color
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
 
 func TestColorCreation(t *testing.T) {
 	c := New(-0.5, 0.4, 1.7)
@@ -43,7 +47,7 @@ func TestColormultiply(t *testing.T) {
 	}
 }
 
-func TestalternativeConstructors(t *testing.T) {
+func TestAlternativeConstructors(t *testing.T) {
 	type cases struct {
 		result   Color
 		expected Color
@@ -54,11 +58,12 @@ func TestalternativeConstructors(t *testing.T) {
 		{Hex(0x00ff00), New(0, 1, 0)},
 		{Hex(0x0000ff), New(0, 0, 1)},
 		{Hex(0xff0000), New(1, 0, 0)},
-		{Hex(0x5F9EA0), New(0.37, 0.62, 0.63)},
+		{Hex(0x5F9EA0), New(0.114, 0.342, 0.352)},
 		{Bytes(255, 0, 0), New(1, 0, 0)},
 		{Bytes(0, 255, 0), New(0, 1, 0)},
 		{Bytes(0, 0, 255), New(0, 0, 1)},
-		{Bytes(95, 158, 160), New(0.37, 0.62, 0.63)},
+		{Bytes(95, 158, 160), New(0.114, 0.342, 0.352)},
+		{Bytes(128, 128, 128), New(0.216, 0.216, 0.216)},
 	}
 	for _, c := range tests {
 		if !c.result.EqualToTolerance(c.expected, 0.005) {
@@ -66,3 +71,14 @@ func TestalternativeConstructors(t *testing.T) {
 		}
 	}
 }
+
+func TestSRGBRoundTrip(t *testing.T) {
+	for _, v := range []float64{0, 0.001, 0.04045, 0.2, 0.5, 1} {
+		if got := EncodeSRGB(DecodeSRGB(v)); !utils.AlmostEqual(got, v) {
+			t.Errorf("sRGB round trip of %v gave %v", v, got)
+		}
+	}
+	if got := New(0.5, 0, 1).ToSRGB(); !got.EqualToTolerance(New(0.735, 0, 1), 0.001) {
+		t.Errorf("Wrong sRGB encoding. Got %v", got)
+	}
+}
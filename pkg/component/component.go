@@ -3,8 +3,9 @@ package component
 import "github.com/bricef/ray-tracer/pkg/core"
 
 const (
-	Mesh       core.ComponentType = 1
-	Kinematic  core.ComponentType = 2
-	Material   core.ComponentType = 3
-	PointLight core.ComponentType = 4
+	Mesh      core.ComponentType = 1
+	Kinematic core.ComponentType = 2
+	Material  core.ComponentType = 3
+	Light     core.ComponentType = 4
+	CSG       core.ComponentType = 5
 )
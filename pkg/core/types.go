@@ -17,6 +17,21 @@ type Dynamic interface {
 
 type Shader func(p math.Point) color.Color
 
+// NormalMap bends the normal n of a surface at p, both in object space, to
+// fake detail the geometry doesn't have.
+type NormalMap func(p math.Point, n math.Vector) math.Vector
+
+// ShadingModel decides how a material reflects direct light.
+type ShadingModel int
+
+const (
+	// Phong uses the ambient, diffuse, specular and shininess parameters.
+	Phong ShadingModel = iota
+	// Microfacet uses the metallic and roughness parameters, with a GGX
+	// distribution of microfacets.
+	Microfacet
+)
+
 type Material interface {
 	Component
 	Equal(o Material) bool
@@ -27,10 +42,23 @@ type Material interface {
 	SetReflective(v float64) Material
 	SetRefractiveIndex(v float64) Material
 	SetTransparency(v float64) Material
+	SetCastsShadow(v bool) Material
+	SetTintsShadow(v bool) Material
 	SetColor(c color.Color) Material
 	SetShader(s Shader) Material
+	SetNormalMap(n NormalMap) Material
+	SetModel(m ShadingModel) Material
+	SetMetallic(v float64) Material
+	SetRoughness(v float64) Material
+	SetEmission(c color.Color) Material
+	SetReflectionRoughness(v float64) Material
+	SetRefractionRoughness(v float64) Material
+	SetEmissionStrength(v float64) Material
 	ColorAt(math.Point) color.Color
 	ColorOn(Entity, math.Point) color.Color
+	// Shading normal of the entity at a point of its surface, given the true
+	// normal there. Both the point and normals are in world space.
+	NormalOn(e Entity, worldPoint math.Point, worldNormal math.Vector) math.Vector
 	Color() color.Color
 	Ambient() float64
 	Diffuse() float64
@@ -39,6 +67,22 @@ type Material interface {
 	Reflective() float64
 	Transparency() float64
 	RefractiveIndex() float64
+	// Spread of reflected and refracted rays around the perfect directions,
+	// from 0 for a mirror and clear glass to 1
+	ReflectionRoughness() float64
+	RefractionRoughness() float64
+	Model() ShadingModel
+	// How much the surface behaves as a metal, from 0 to 1
+	Metallic() float64
+	// Spread of the microfacet normals, from 0 for a mirror to 1
+	Roughness() float64
+	// Light given off by the surface, the emission color scaled by its
+	// strength
+	Emission() color.Color
+	// Whether the material blocks light from reaching surfaces behind it
+	CastsShadow() bool
+	// Whether light going through the material takes on its color
+	TintsShadow() bool
 }
 
 type Ray interface {
@@ -53,6 +97,18 @@ type Mesh interface {
 	Component
 	Normal(meshPoint math.Point) math.Vector
 	Intersect(Ray) []float64
+	Bounds() math.Bounds
+}
+
+// Surface is a bounded mesh that can pick points on its surface, so that
+// emissive entities made of it can be sampled like area lights.
+type Surface interface {
+	Mesh
+	// Random point of the surface, uniformly distributed by area, and the
+	// geometric normal there, both in object space.
+	Sample() (math.Point, math.Vector)
+	// Area of the surface in object space
+	Area() float64
 }
 
 type Kinematic interface {
@@ -62,9 +118,29 @@ type Kinematic interface {
 	SetVelocity(math.Vector) Kinematic
 }
 
-type PointLight interface {
+type Light interface {
 	Component
 	Intensity() color.Color
+	// Direction and distance from the point to the light owned by the entity.
+	// Lights at infinity are at an infinite distance.
+	Towards(owner Entity, p math.Point) (math.Vector, float64)
+	// Intensity of the light owned by the entity that reaches the point
+	IntensityAt(owner Entity, p math.Point) color.Color
+}
+
+type AreaLight interface {
+	Light
+	// Points on the light to test for shadows, in the light entity's space.
+	// Jittered lights return different points on every call.
+	Samples() []math.Point
+}
+
+type CSG interface {
+	Component
+	// Allowed decides whether an intersection survives the operation, given
+	// whether it hit the left operand and whether the ray is currently inside
+	// the left and right operands.
+	Allowed(leftHit, inLeft, inRight bool) bool
 }
 
 type Entity interface {
@@ -96,6 +172,8 @@ type Entity interface {
 	// Utilities for normal calculations w.r.t Groups
 	WorldPointToObjectPoint(worldPoint math.Point) math.Point
 	ObjectNormalToWorldNormal(objectNormal math.Vector) math.Vector
+	// Bounding box of the entity and its children, in its parent's space
+	Bounds() math.Bounds
 
 	// Update method
 	Tick(scene []Entity)
@@ -104,7 +182,8 @@ type Entity interface {
 	GetMesh() Mesh
 	GetMaterial() Material
 	GetKinematic() Kinematic
-	GetLight() PointLight
+	GetLight() Light
+	GetCSG() CSG
 
 	// Utilities
 	String() string
@@ -120,6 +199,17 @@ func Contains(es []Entity, e Entity) bool {
 	}
 	return false
 }
+
+// IsDescendant reports whether e is ancestor or one of its descendants.
+func IsDescendant(e Entity, ancestor Entity) bool {
+	for ; e != nil; e = e.Parent() {
+		if e == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
 func Remove(es []Entity, e Entity) []Entity {
 	for i, a := range es {
 		if a == e {
@@ -0,0 +1,62 @@
+package csg
+
+import (
+	"fmt"
+
+	"github.com/bricef/ray-tracer/pkg/component"
+	"github.com/bricef/ray-tracer/pkg/core"
+)
+
+type Operation int
+
+const (
+	Union Operation = iota
+	Intersection
+	Difference
+)
+
+func (o Operation) String() string {
+	switch o {
+	case Union:
+		return "union"
+	case Intersection:
+		return "intersection"
+	case Difference:
+		return "difference"
+	}
+	return fmt.Sprintf("Operation(%d)", int(o))
+}
+
+type csg struct {
+	operation Operation
+}
+
+// New creates a CSG component. The entity carrying it combines its first two
+// children, the left and right operands.
+func New(op Operation) core.CSG {
+	return &csg{op}
+}
+
+func (c *csg) Type() core.ComponentType {
+	return component.CSG
+}
+
+func (c *csg) Operation() Operation {
+	return c.operation
+}
+
+func (c *csg) Allowed(leftHit, inLeft, inRight bool) bool {
+	switch c.operation {
+	case Union:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case Intersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case Difference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+	return false
+}
+
+func (c *csg) String() string {
+	return fmt.Sprintf("CSG(%v)", c.operation)
+}
@@ -0,0 +1,53 @@
+package csg_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/csg"
+)
+
+func TestCSGOperationRules(t *testing.T) {
+	type Case struct {
+		op      csg.Operation
+		leftHit bool
+		inLeft  bool
+		inRight bool
+		allowed bool
+	}
+
+	cases := []Case{
+		{csg.Union, true, true, true, false},
+		{csg.Union, true, true, false, true},
+		{csg.Union, true, false, true, false},
+		{csg.Union, true, false, false, true},
+		{csg.Union, false, true, true, false},
+		{csg.Union, false, true, false, false},
+		{csg.Union, false, false, true, true},
+		{csg.Union, false, false, false, true},
+
+		{csg.Intersection, true, true, true, true},
+		{csg.Intersection, true, true, false, false},
+		{csg.Intersection, true, false, true, true},
+		{csg.Intersection, true, false, false, false},
+		{csg.Intersection, false, true, true, true},
+		{csg.Intersection, false, true, false, true},
+		{csg.Intersection, false, false, true, false},
+		{csg.Intersection, false, false, false, false},
+
+		{csg.Difference, true, true, true, false},
+		{csg.Difference, true, true, false, true},
+		{csg.Difference, true, false, true, false},
+		{csg.Difference, true, false, false, true},
+		{csg.Difference, false, true, true, true},
+		{csg.Difference, false, true, false, true},
+		{csg.Difference, false, false, true, false},
+		{csg.Difference, false, false, false, false},
+	}
+
+	for _, c := range cases {
+		result := csg.New(c.op).Allowed(c.leftHit, c.inLeft, c.inRight)
+		if result != c.allowed {
+			t.Errorf("Wrong %v rule for leftHit=%v, inLeft=%v, inRight=%v. Expected %v, got %v", c.op, c.leftHit, c.inLeft, c.inRight, c.allowed, result)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/csg"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+func TestCSGConstruction(t *testing.T) {
+	s1 := entities.NewSphere()
+	s2 := entities.NewCube()
+	c := entities.NewCSG(csg.Union, s1, s2)
+
+	if c.GetCSG() == nil {
+		t.Errorf("CSG entity should carry a CSG component")
+	}
+	if c.Children()[0] != s1 || c.Children()[1] != s2 || s1.Parent() != c || s2.Parent() != c {
+		t.Errorf("CSG entity should have its operands as children")
+	}
+}
+
+func TestCSGFiltersIntersections(t *testing.T) {
+	type Case struct {
+		op       csg.Operation
+		expected []float64
+	}
+
+	// Overlapping unit spheres: left spans z=[-1,1], right spans z=[0,2]
+	cases := []Case{
+		{csg.Union, []float64{4, 7}},
+		{csg.Intersection, []float64{5, 6}},
+		{csg.Difference, []float64{4, 5}},
+	}
+
+	for _, c := range cases {
+		s1 := entities.NewSphere()
+		s2 := entities.NewSphere().Translate(0, 0, 1)
+		shape := entities.NewCSG(c.op, s1, s2)
+		r := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+
+		xs := r.GetIntersections([]core.Entity{shape})
+		if len(xs.All) != len(c.expected) {
+			t.Errorf("Expected %v intersections for %v, got %v", len(c.expected), c.op, len(xs.All))
+			continue
+		}
+		for i, x := range xs.All {
+			if !utils.AlmostEqual(x.T, c.expected[i]) {
+				t.Errorf("Wrong %v intersection %v. Expected t=%v, got %v", c.op, i, c.expected[i], x.T)
+			}
+		}
+	}
+}
+
+func TestRayMissesCSG(t *testing.T) {
+	c := entities.NewCSG(csg.Union, entities.NewSphere(), entities.NewCube())
+	r := ray.NewRay(math.NewPoint(0, 2, -5), math.NewVector(0, 0, 1))
+
+	xs := r.GetIntersections([]core.Entity{c})
+	if len(xs.All) != 0 {
+		t.Errorf("Expected ray to miss CSG shape. Got %v", xs.All)
+	}
+}
+
+func TestRayHitsCSG(t *testing.T) {
+	s1 := entities.NewSphere()
+	s2 := entities.NewSphere().Translate(0, 0, 0.5)
+	c := entities.NewCSG(csg.Union, s1, s2)
+	r := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+
+	xs := r.GetIntersections([]core.Entity{c})
+	if len(xs.All) != 2 {
+		t.Fatalf("Expected 2 intersections with CSG union. Got %v", len(xs.All))
+	}
+	if !utils.AlmostEqual(xs.All[0].T, 4) || xs.All[0].Entity != s1 {
+		t.Errorf("Expected first intersection on s1 at t=4. Got %v", xs.All[0])
+	}
+	if !utils.AlmostEqual(xs.All[1].T, 6.5) || xs.All[1].Entity != s2 {
+		t.Errorf("Expected second intersection on s2 at t=6.5. Got %v", xs.All[1])
+	}
+}
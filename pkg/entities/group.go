@@ -2,6 +2,7 @@ package entities
 
 import (
 	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/csg"
 	"github.com/bricef/ray-tracer/pkg/entity"
 )
 
@@ -12,3 +13,11 @@ func NewGroup(es ...core.Entity) core.Entity {
 	}
 	return e
 }
+
+func NewCSG(op csg.Operation, left core.Entity, right core.Entity) core.Entity {
+	return entity.NewEntity().
+		AddComponent(csg.New(op)).
+		AddChild(left).
+		AddChild(right).
+		SetName("CSG")
+}
@@ -5,10 +5,12 @@ import (
 	"testing"
 
 	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/csg"
 	"github.com/bricef/ray-tracer/pkg/entities"
 	"github.com/bricef/ray-tracer/pkg/entity"
 	"github.com/bricef/ray-tracer/pkg/math"
 	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/utils"
 )
 
 func TestGroupInitialisation(t *testing.T) {
@@ -167,3 +169,57 @@ func TestNormalOfChildInGroup(t *testing.T) {
 	}
 
 }
+
+func TestGroupBoundsContainTransformedChildren(t *testing.T) {
+	s := entities.NewSphere().Translate(2, 5, -3).Scale(2, 2, 2)
+	c := entities.NewCylinder().Translate(-4, -1, 4).Scale(0.5, 1, 0.5)
+	g := entities.NewGroup(s, c).Translate(1, 0, 0)
+
+	b := g.Bounds()
+	expected := math.NewBounds(math.NewPoint(-3.5, -1, -5), math.NewPoint(5, 7, 4.5))
+	if !b.Min.Equal(expected.Min) || !b.Max.Equal(expected.Max) {
+		t.Errorf("Group bounds incorrect. Expected %v, got %v", expected, b)
+	}
+}
+
+func TestDeeplyNestedGroupsIntersect(t *testing.T) {
+	s := entities.NewSphere().Translate(0, 0, 1)
+	g3 := entities.NewGroup(s).Scale(1, 1, 2)
+	g2 := entities.NewGroup(g3).Translate(3, 0, 0)
+	g1 := entities.NewGroup(g2).RotateY(m.Pi / 2.0)
+
+	// Sphere spans z=[0,4] in g2 space, so x=[0,4] in world space after the
+	// rotation, centred on z=-3.
+	r := ray.NewRay(
+		math.NewPoint(-5, 0, -3),
+		math.NewVector(1, 0, 0),
+	)
+
+	xs := r.GetIntersections([]core.Entity{g1})
+
+	if len(xs.All) != 2 {
+		t.Fatalf("Expected 2 intersections with deeply nested sphere. Got %v", len(xs.All))
+	}
+	if !utils.AlmostEqual(xs.All[0].T, 5) || !utils.AlmostEqual(xs.All[1].T, 9) {
+		t.Errorf("Wrong intersections with nested sphere. Expected 5 and 9, got %v and %v", xs.All[0].T, xs.All[1].T)
+	}
+	if xs.Hit.Entity != s || !xs.Hit.Point.Equal(math.NewPoint(0, 0, -3)) {
+		t.Errorf("Expected world space hit on nested sphere at %v. Got %v", math.NewPoint(0, 0, -3), xs.Hit.Point)
+	}
+	if !xs.Hit.Normal.Equal(math.NewVector(-1, 0, 0)) {
+		t.Errorf("Expected world space normal %v, got %v", math.NewVector(-1, 0, 0), xs.Hit.Normal)
+	}
+}
+
+func TestTransformedCSGInGroupIntersect(t *testing.T) {
+	s1 := entities.NewSphere()
+	s2 := entities.NewSphere().Translate(0, 0, 0.5)
+	c := entities.NewCSG(csg.Union, s1, s2).Translate(0, 0, 1)
+	g := entities.NewGroup(c).Translate(0, 0, 1)
+	r := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+
+	xs := r.GetIntersections([]core.Entity{g})
+	if len(xs.All) != 2 || !utils.AlmostEqual(xs.All[0].T, 6) || !utils.AlmostEqual(xs.All[1].T, 8.5) {
+		t.Errorf("Expected CSG union intersections at 6 and 8.5. Got %v", xs.All)
+	}
+}
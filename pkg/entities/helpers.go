@@ -4,6 +4,7 @@ import (
 	"github.com/bricef/ray-tracer/pkg/core"
 	"github.com/bricef/ray-tracer/pkg/entity"
 	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
 	"github.com/bricef/ray-tracer/pkg/meshes"
 )
 
@@ -51,3 +52,30 @@ func NewCappedCylinder() core.Entity {
 		AddComponent(material.NewMaterial()).
 		SetName("CappedCylinder")
 }
+
+func NewCone() core.Entity {
+	return entity.NewEntity().
+		AddComponent(meshes.ConeMeshLimited(0, 1)).
+		AddComponent(material.NewMaterial()).
+		SetName("Cone")
+}
+func NewCappedCone() core.Entity {
+	return entity.NewEntity().
+		AddComponent(meshes.ConeClosedMesh(0, 1)).
+		AddComponent(material.NewMaterial()).
+		SetName("CappedCone")
+}
+
+func NewTriangle(p1, p2, p3 math.Point) core.Entity {
+	return entity.NewEntity().
+		AddComponent(meshes.TriangleMesh(p1, p2, p3)).
+		AddComponent(material.NewMaterial()).
+		SetName("Triangle")
+}
+
+func NewSmoothTriangle(p1, p2, p3 math.Point, n1, n2, n3 math.Vector) core.Entity {
+	return entity.NewEntity().
+		AddComponent(meshes.SmoothTriangleMesh(p1, p2, p3, n1, n2, n3)).
+		AddComponent(material.NewMaterial()).
+		SetName("SmoothTriangle")
+}
@@ -158,6 +158,17 @@ func (e *EntityNode) ObjectNormalToWorldNormal(objectNormal math.Vector) math.Ve
 	return n
 }
 
+func (e *EntityNode) Bounds() math.Bounds {
+	b := math.EmptyBounds()
+	if mesh := e.GetMesh(); mesh != nil {
+		b = b.Merge(mesh.Bounds())
+	}
+	for _, child := range e.children {
+		b = b.Merge(child.Bounds())
+	}
+	return b.Transform(e.transform)
+}
+
 func (e *EntityNode) Tick(scene []core.Entity) {
 
 	// Tick all dynamic components
@@ -198,9 +209,16 @@ func (e *EntityNode) GetMesh() core.Mesh {
 	return nil
 }
 
-func (e *EntityNode) GetLight() core.PointLight {
-	if c := e.GetComponent(component.PointLight); c != nil {
-		return c.(core.PointLight)
+func (e *EntityNode) GetLight() core.Light {
+	if c := e.GetComponent(component.Light); c != nil {
+		return c.(core.Light)
+	}
+	return nil
+}
+
+func (e *EntityNode) GetCSG() core.CSG {
+	if c := e.GetComponent(component.CSG); c != nil {
+		return c.(core.CSG)
 	}
 	return nil
 }
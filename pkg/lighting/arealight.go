@@ -0,0 +1,92 @@
+package lighting
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/component"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entity"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// areaLight is a rectangular light divided in usteps x vsteps cells, each
+// contributing one shadow sample. The cells are relative to the center of the
+// light, which is the position of the owning entity.
+type areaLight struct {
+	intensity color.Color
+	corner    math.Vector
+	uvec      math.Vector
+	usteps    int
+	vvec      math.Vector
+	vsteps    int
+	jitter    bool
+}
+
+// NewAreaLight creates a light spanning the parallelogram with the given
+// corner and edges. Jittered lights sample a random point in each cell
+// instead of its center, trading banding for noise.
+func NewAreaLight(
+	intensity color.Color,
+	corner math.Point,
+	uvec math.Vector, usteps int,
+	vvec math.Vector, vsteps int,
+	jitter bool,
+) core.Entity {
+	center := corner.Add(uvec.Scale(0.5)).Add(vvec.Scale(0.5))
+	l := &areaLight{
+		intensity: intensity,
+		corner:    corner.Sub(center).AsVector(),
+		uvec:      uvec.Scale(1.0 / float64(usteps)).AsVector(),
+		usteps:    usteps,
+		vvec:      vvec.Scale(1.0 / float64(vsteps)).AsVector(),
+		vsteps:    vsteps,
+		jitter:    jitter,
+	}
+	return entity.NewEntity().
+		AddComponent(l).
+		SetName("AreaLight").
+		Translate(center.X(), center.Y(), center.Z())
+}
+
+func (l *areaLight) Intensity() color.Color {
+	return l.intensity
+}
+
+// Towards treats the light as a point light at its center. Shading uses the
+// samples instead.
+func (l *areaLight) Towards(owner core.Entity, p math.Point) (math.Vector, float64) {
+	path := owner.Position().Sub(p).AsVector()
+	return path.Normalize(), path.Magnitude()
+}
+
+func (l *areaLight) IntensityAt(owner core.Entity, p math.Point) color.Color {
+	return l.intensity
+}
+
+func (l *areaLight) Samples() []math.Point {
+	ps := make([]math.Point, 0, l.usteps*l.vsteps)
+	for v := 0; v < l.vsteps; v++ {
+		for u := 0; u < l.usteps; u++ {
+			du, dv := 0.5, 0.5
+			if l.jitter {
+				du, dv = rand.Float64(), rand.Float64()
+			}
+			p := math.NewPoint(0, 0, 0).
+				Add(l.corner).
+				Add(l.uvec.Scale(float64(u) + du)).
+				Add(l.vvec.Scale(float64(v) + dv))
+			ps = append(ps, p.AsPoint())
+		}
+	}
+	return ps
+}
+
+func (l *areaLight) Type() core.ComponentType {
+	return component.Light
+}
+
+func (l *areaLight) String() string {
+	return fmt.Sprintf("AreaLight(%v, %v, %vx%v)", l.intensity, l.corner, l.usteps, l.vsteps)
+}
@@ -0,0 +1,94 @@
+package lighting_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+func TestAreaLightSamplesCellCenters(t *testing.T) {
+	le := lighting.NewAreaLight(
+		color.White,
+		math.NewPoint(0, 0, 0),
+		math.NewVector(2, 0, 0), 4,
+		math.NewVector(0, 0, 1), 2,
+		false,
+	)
+	if !le.Position().Equal(math.NewPoint(1, 0, 0.5)) {
+		t.Errorf("Area light should be positioned at its center. Got %v", le.Position())
+	}
+
+	samples := le.GetLight().(core.AreaLight).Samples()
+	expected := []math.Point{
+		math.NewPoint(0.25, 0, 0.25),
+		math.NewPoint(0.75, 0, 0.25),
+		math.NewPoint(1.25, 0, 0.25),
+		math.NewPoint(1.75, 0, 0.25),
+		math.NewPoint(0.25, 0, 0.75),
+		math.NewPoint(0.75, 0, 0.75),
+		math.NewPoint(1.25, 0, 0.75),
+		math.NewPoint(1.75, 0, 0.75),
+	}
+	if len(samples) != len(expected) {
+		t.Fatalf("Expected %v samples, got %v", len(expected), len(samples))
+	}
+	for i, s := range samples {
+		world := le.Transform().Apply(s)
+		if !world.Equal(expected[i]) {
+			t.Errorf("Sample %v at wrong position. Expected %v, got %v", i, expected[i], world)
+		}
+	}
+}
+
+func TestJitteredAreaLightSamplesStayInCells(t *testing.T) {
+	le := lighting.NewAreaLight(
+		color.White,
+		math.NewPoint(-1, 5, -1),
+		math.NewVector(2, 0, 0), 2,
+		math.NewVector(0, 0, 2), 2,
+		true,
+	)
+	for i, s := range le.GetLight().(core.AreaLight).Samples() {
+		p := le.Transform().Apply(s)
+		minX, minZ := -1+float64(i%2), -1+float64(i/2)
+		if p.X() < minX || p.X() > minX+1 || p.Z() < minZ || p.Z() > minZ+1 || p.Y() != 5 {
+			t.Errorf("Jittered sample %v outside of its cell: %v", i, p)
+		}
+	}
+}
+
+func TestAreaLightCastsPenumbra(t *testing.T) {
+	s := scene.NewScene()
+	s.Add(lighting.NewAreaLight(
+		color.White,
+		math.NewPoint(-1, 10, -1),
+		math.NewVector(2, 0, 0), 2,
+		math.NewVector(0, 0, 2), 1,
+		false,
+	))
+	s.Add(entities.NewPlane())
+	// Occluder covering the x < 0 half of the floor
+	s.Add(entities.NewCube().Translate(-5, 5, 0).Scale(5, 0.1, 5))
+
+	contribution := func(x float64) color.Color {
+		r := ray.NewRay(math.NewPoint(x, 1, -1), math.NewVector(0, -1, 1).Normalize())
+		hit := s.Intersections(r).Hit
+		return scene.Whitted{}.LightContribution(s, s.Lights()[0], hit)
+	}
+	lit := contribution(5)
+	penumbra := contribution(0)
+	shadow := contribution(-5)
+
+	if !shadow.Equal(color.White.Scale(0.1)) {
+		t.Errorf("Fully occluded point should only receive ambient light. Got %v", shadow)
+	}
+	if !(shadow.R < penumbra.R && penumbra.R < lit.R) {
+		t.Errorf("Expected partially occluded point to be lit between %v and %v. Got %v", shadow, lit, penumbra)
+	}
+}
@@ -0,0 +1,48 @@
+package lighting
+
+import (
+	"fmt"
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/component"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entity"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// directionalLight is a light at infinity, like the sun. All its rays are
+// parallel and it does not fade with distance. Its position is irrelevant,
+// but rotating the entity turns the direction.
+type directionalLight struct {
+	intensity color.Color
+	direction math.Vector
+}
+
+// NewDirectionalLight creates a light shining along the given direction.
+func NewDirectionalLight(intensity color.Color, direction math.Vector) core.Entity {
+	return entity.NewEntity().
+		AddComponent(&directionalLight{intensity, direction.Normalize()}).
+		SetName("DirectionalLight")
+}
+
+func (l *directionalLight) Intensity() color.Color {
+	return l.intensity
+}
+
+func (l *directionalLight) Towards(owner core.Entity, p math.Point) (math.Vector, float64) {
+	direction := owner.Transform().Apply(l.direction).AsVector()
+	return direction.Invert().Normalize(), m.Inf(1)
+}
+
+func (l *directionalLight) IntensityAt(owner core.Entity, p math.Point) color.Color {
+	return l.intensity
+}
+
+func (l *directionalLight) Type() core.ComponentType {
+	return component.Light
+}
+
+func (l *directionalLight) String() string {
+	return fmt.Sprintf("DirectionalLight(%v, %v)", l.intensity, l.direction)
+}
@@ -1,7 +1,7 @@
 package lighting
 
 import (
-	"fmt"
+	"errors"
 	m "math"
 
 	"github.com/bricef/ray-tracer/pkg/color"
@@ -9,48 +9,110 @@ import (
 	"github.com/bricef/ray-tracer/pkg/math"
 )
 
+var (
+	// ErrNoLight is returned when shading with an entity that is not a light.
+	ErrNoLight = errors.New("entity has no light")
+	// ErrNoMaterial is returned when shading a surface without material.
+	ErrNoMaterial = errors.New("nil material")
+	// ErrNotAreaLight is returned by PhongArea for other kinds of lights.
+	ErrNotAreaLight = errors.New("light is not an area light")
+)
+
+// Phong shades a point lit by a light, with an ambient term standing in for
+// indirect light. The direct light is computed by Direct, so materials with
+// the microfacet model are shaded physically.
 func Phong(
 	mat core.Material,
 	le core.Entity,
 	point math.Point,
 	eye math.Vector,
 	normal math.Vector,
-) color.Color {
-	// surface color + light color
+) (color.Color, error) {
 	l := le.GetLight()
-	effectiveColor := mat.ColorAt(point).Mult(l.Intensity())
-
-	// Direction to light
-	lightVector := le.Position().Sub(point).AsVector().Normalize()
+	if l == nil {
+		return color.Black, ErrNoLight
+	}
+	if mat == nil {
+		return color.Black, ErrNoMaterial
+	}
+	surface := mat.ColorAt(point)
 
 	// Ambient contribution
-	ambient := effectiveColor.Scale(mat.Ambient())
+	ambient := surface.Mult(l.Intensity()).Scale(mat.Ambient())
 
-	var diffuse color.Color
-	var specular color.Color
+	// fmt.Printf("Ambient: %v\nDiffuse: %v\nSpecular: %v\n", ambient, diffuse, specular)
+	// fmt.Printf("Total: %v\n\n", ambient.Add(diffuse).Add(specular))
 
-	dot := lightVector.Dot(normal)
-	if dot < 0 { // light on other side of surface
-		diffuse = color.Black
-		specular = color.Black
-	} else {
-		diffuse = effectiveColor.Scale(mat.Diffuse() * dot)
+	lightVector, _ := l.Towards(le, point)
+	return ambient.Add(Direct(mat, surface, l.IntensityAt(le, point), lightVector, eye, normal)), nil
+}
 
-		reflectVector := lightVector.Invert().Reflect(normal)
-		reflectFactor := reflectVector.Dot(eye)
+// PhongArea shades a point lit by an area light. Each sample of the light
+// adds its share of the diffuse and specular contributions, filtered by the
+// fraction of its light reaching the point, so partially occluded points fall
+// in a penumbra.
+func PhongArea(
+	mat core.Material,
+	le core.Entity,
+	point math.Point,
+	eye math.Vector,
+	normal math.Vector,
+	transmittance func(sample math.Point) color.Color,
+) (color.Color, error) {
+	l, ok := le.GetLight().(core.AreaLight)
+	if !ok {
+		return color.Black, ErrNotAreaLight
+	}
+	if mat == nil {
+		return color.Black, ErrNoMaterial
+	}
+	surface := mat.ColorAt(point)
+	c := surface.Mult(l.Intensity()).Scale(mat.Ambient())
 
-		if reflectFactor <= 0 { //light reflects away from eye
-			specular = color.Black
-		} else {
-			factor := m.Pow(reflectFactor, mat.Shininess())
-			specular = l.Intensity().Scale(mat.Specular() * factor)
+	samples := l.Samples()
+	for _, s := range samples {
+		position := le.Transform().Apply(s).AsPoint()
+		filter := transmittance(position)
+		if filter.Equal(color.Black) {
+			continue
 		}
+		lightVector := position.Sub(point).AsVector().Normalize()
+		c = c.Add(Direct(mat, surface, l.Intensity().Mult(filter), lightVector, eye, normal).
+			Scale(1.0 / float64(len(samples))))
 	}
+	return c, nil
+}
 
-	// fmt.Printf("Ambient: %v\nDiffuse: %v\nSpecular: %v\n", ambient, diffuse, specular)
-	// fmt.Printf("Total: %v\n\n", ambient.Add(diffuse).Add(specular))
+// Direct is the diffuse and specular contribution of light of the given
+// intensity arriving from the direction of lightVector, on a surface of the
+// given color. Unlike Phong it has no ambient term. Materials with the
+// microfacet shading model are shaded with Microfacet instead.
+func Direct(
+	mat core.Material,
+	surface color.Color,
+	intensity color.Color,
+	lightVector math.Vector,
+	eye math.Vector,
+	normal math.Vector,
+) color.Color {
+	if mat.Model() == core.Microfacet {
+		return Microfacet(mat, surface, intensity, lightVector, eye, normal)
+	}
+	dot := lightVector.Dot(normal)
+	if dot < 0 { // light on other side of surface
+		return color.Black
+	}
+	diffuse := surface.Mult(intensity).Scale(mat.Diffuse() * dot)
+
+	reflectVector := lightVector.Invert().Reflect(normal)
+	reflectFactor := reflectVector.Dot(eye)
 
-	return specular.Add(ambient).Add(diffuse)
+	if reflectFactor <= 0 { //light reflects away from eye
+		return diffuse
+	}
+	factor := m.Pow(reflectFactor, mat.Shininess())
+	specular := intensity.Scale(mat.Specular() * factor)
+	return specular.Add(diffuse)
 }
 
 func PhongShadow(
@@ -59,15 +121,15 @@ func PhongShadow(
 	point math.Point,
 	eye math.Vector,
 	normal math.Vector,
-) color.Color {
+) (color.Color, error) {
 	l := le.GetLight()
 	if l == nil {
-		panic(fmt.Errorf("nil light passed to PhongShadow()"))
+		return color.Black, ErrNoLight
 	}
 	if m == nil {
-		panic(fmt.Errorf("nil material passed to PhongShadow()"))
+		return color.Black, ErrNoMaterial
 	}
 	effectiveColor := m.ColorAt(point).Mult(l.Intensity())
 	ambient := effectiveColor.Scale(m.Ambient())
-	return ambient
+	return ambient, nil
 }
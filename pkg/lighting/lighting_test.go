@@ -70,13 +70,16 @@ func TestPhongLightingScenarios(t *testing.T) {
 
 	for _, c := range cases {
 		fmt.Printf("[CASE]: %v\n", c)
-		result := lighting.Phong(
+		result, err := lighting.Phong(
 			c.Material,
 			c.Light,
 			c.Position,
 			c.Eye,
 			c.Normal,
 		)
+		if err != nil {
+			t.Errorf("Lighting failed [CASE]: %v. %v", c, err)
+		}
 		if !result.Equal(c.Expected) {
 			t.Errorf("Lighting failed [CASE]: %v. \nExpected %v, \ngot %v", c, c.Expected, result)
 		}
@@ -90,10 +93,30 @@ func TestLightingWithShadow(t *testing.T) {
 	normal := math.NewVector(0, 0, -1)
 	l := lighting.NewPointLight(color.White).Translate(0, 0, -10)
 
-	result := lighting.PhongShadow(mat, l, pos, eyev, normal)
+	result, err := lighting.PhongShadow(mat, l, pos, eyev, normal)
 	expected := color.New(.1, .1, .1)
-	if !result.Equal(expected) {
-		t.Errorf("Shadowed Lighting failed. Expected %v, got %v", expected, result)
+	if err != nil || !result.Equal(expected) {
+		t.Errorf("Shadowed Lighting failed. Expected %v, got %v (%v)", expected, result, err)
+	}
+}
+
+func TestLightingErrors(t *testing.T) {
+	pos := math.NewPoint(0, 0, 0)
+	eyev := math.NewVector(0, 0, -1)
+	normal := math.NewVector(0, 0, -1)
+	l := lighting.NewPointLight(color.White).Translate(0, 0, -10)
+
+	if _, err := lighting.PhongShadow(nil, l, pos, eyev, normal); err != lighting.ErrNoMaterial {
+		t.Errorf("Expected %v shading without material. Got %v", lighting.ErrNoMaterial, err)
+	}
+	if _, err := lighting.Phong(nil, l, pos, eyev, normal); err != lighting.ErrNoMaterial {
+		t.Errorf("Expected %v shading without material. Got %v", lighting.ErrNoMaterial, err)
+	}
+	if _, err := lighting.Phong(material.NewMaterial(), entities.NewSphere(), pos, eyev, normal); err != lighting.ErrNoLight {
+		t.Errorf("Expected %v shading with a sphere as light. Got %v", lighting.ErrNoLight, err)
+	}
+	if _, err := lighting.PhongArea(material.NewMaterial(), l, pos, eyev, normal, nil); err != lighting.ErrNotAreaLight {
+		t.Errorf("Expected %v shading with a point light as area light. Got %v", lighting.ErrNotAreaLight, err)
 	}
 }
 
@@ -134,7 +157,7 @@ func TestIsShadowed(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		result := c.Scene.Obstructed(c.Light.Position(), c.Point)
+		result := c.Scene.Obstructed(c.Point, c.Light)
 		if result != c.Expected {
 			t.Errorf("ShadowTracing Failed [CASE]: %v. Expected %v, got %v", c, c.Expected, result)
 		}
@@ -156,7 +179,7 @@ func TestShadowHitRenderedCorrectly(t *testing.T) {
 		math.NewVector(0, 0, 1),
 	)
 
-	result := s.Cast(r)
+	result := scene.Whitted{}.Radiance(s, r)
 
 	expected := color.New(0.1, 0.1, 0.1)
 	if !result.Equal(expected) {
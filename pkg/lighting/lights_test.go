@@ -0,0 +1,92 @@
+package lighting_test
+
+import (
+	m "math"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+func TestPointLightTowards(t *testing.T) {
+	le := lighting.NewPointLight(color.White).Translate(0, 10, 0)
+	direction, distance := le.GetLight().Towards(le, math.NewPoint(0, 0, 0))
+
+	if !direction.Equal(math.NewVector(0, 1, 0)) || distance != 10 {
+		t.Errorf("Expected point light 10 units up. Got %v at %v", direction, distance)
+	}
+}
+
+func TestDirectionalLightIsAtInfinity(t *testing.T) {
+	le := lighting.NewDirectionalLight(color.White, math.NewVector(0, -2, 0))
+
+	for _, p := range []math.Point{math.NewPoint(0, 0, 0), math.NewPoint(100, -50, 3)} {
+		direction, distance := le.GetLight().Towards(le, p)
+		if !direction.Equal(math.NewVector(0, 1, 0)) || !m.IsInf(distance, 1) {
+			t.Errorf("Expected sun straight up at infinity from %v. Got %v at %v", p, direction, distance)
+		}
+	}
+
+	rotated := lighting.NewDirectionalLight(color.White, math.NewVector(0, -1, 0)).RotateZ(m.Pi / 2)
+	direction, _ := rotated.GetLight().Towards(rotated, math.NewPoint(0, 0, 0))
+	if !direction.Equal(math.NewVector(-1, 0, 0)) {
+		t.Errorf("Expected rotation to turn the light direction. Got %v", direction)
+	}
+}
+
+func TestDirectionalLightShadows(t *testing.T) {
+	s := scene.NewScene()
+	sun := lighting.NewDirectionalLight(color.White, math.NewVector(0, -1, 0))
+	s.Add(sun)
+	s.Add(entities.NewSphere().Translate(0, 1000, 0))
+
+	if !s.Obstructed(math.NewPoint(0, 0, 0), sun) {
+		t.Errorf("Sphere far above should shadow the origin from the sun")
+	}
+	if s.Obstructed(math.NewPoint(5, 0, 0), sun) {
+		t.Errorf("Point beside the sphere should not be in shadow")
+	}
+}
+
+func TestSpotLightCone(t *testing.T) {
+	le := lighting.NewSpotLight(color.White, math.NewVector(0, -1, 0), m.Pi/4, m.Pi/8).Translate(0, 10, 0)
+	l := le.GetLight()
+
+	type Case struct {
+		point    math.Point
+		expected float64
+	}
+	cases := []Case{
+		{math.NewPoint(0, 0, 0), 1},
+		{math.NewPoint(10*m.Tan(m.Pi/8), 0, 0), 1},
+		{math.NewPoint(10*m.Tan(3*m.Pi/16), 0, 0), 0.5},
+		{math.NewPoint(0, 0, 10), 0},
+		{math.NewPoint(0, 20, 0), 0},
+	}
+	for _, c := range cases {
+		result := l.IntensityAt(le, c.point)
+		if !result.Equal(color.White.Scale(c.expected)) {
+			t.Errorf("Spot light intensity at %v. Expected %v, got %v", c.point, c.expected, result)
+		}
+	}
+}
+
+func TestPhongOutsideSpotLightConeIsAmbient(t *testing.T) {
+	mat := material.NewMaterial()
+	le := lighting.NewSpotLight(color.White, math.NewVector(0, 0, 1), m.Pi/6, 0).Translate(0, 0, -10)
+	normal := math.NewVector(0, 0, -1)
+	eye := math.NewVector(0, 0, -1)
+
+	inside, _ := lighting.Phong(mat, le, math.NewPoint(0, 0, 0), eye, normal)
+	if !inside.Equal(color.New(1.9, 1.9, 1.9)) {
+		t.Errorf("Expected full lighting inside the cone. Got %v", inside)
+	}
+	outside, _ := lighting.Phong(mat, le, math.NewPoint(10, 0, 0), eye, normal)
+	if !outside.Equal(color.New(0.1, 0.1, 0.1)) {
+		t.Errorf("Expected only ambient lighting outside the cone. Got %v", outside)
+	}
+}
@@ -0,0 +1,91 @@
+package lighting
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// Reflectance of dielectrics seen head on, about 4% for most of them
+const dielectricF0 = 0.04
+
+// Microfacet is the diffuse and specular contribution of light of the given
+// intensity arriving from the direction of lightVector, on a metallic-roughness
+// surface of the given base color. The surface is made of tiny mirrors whose
+// normals spread with the roughness of the material:
+//
+//	specular = D * G * F / (4 * (n.l) * (n.v))
+//
+// D is the GGX (Trowbridge-Reitz) distribution of the microfacet normals, G
+// the Smith term for microfacets hiding each other and F the Fresnel
+// reflectance, after Schlick. Light not reflected by the microfacets is
+// diffused by dielectrics and absorbed by metals.
+//
+// Intensities follow the convention of Phong, where a white light shining
+// straight on a white diffuse surface reflects a white color.
+func Microfacet(
+	mat core.Material,
+	surface color.Color,
+	intensity color.Color,
+	lightVector math.Vector,
+	eye math.Vector,
+	normal math.Vector,
+) color.Color {
+	nl := lightVector.Dot(normal)
+	nv := eye.Dot(normal)
+	if nl <= 0 || nv <= 0 {
+		return color.Black
+	}
+	half := lightVector.Add(eye).AsVector().Normalize()
+	nh := m.Max(half.Dot(normal), 0)
+	vh := m.Max(half.Dot(eye), 0)
+
+	alpha := Alpha(mat.Roughness())
+	f := FresnelSchlick(SpecularColor(mat, surface), vh)
+	specular := f.Scale(GGX(nh, alpha) * Smith(nl, nv, alpha) / (4 * nl * nv))
+
+	// The Lambertian term is surface / pi, which the intensity convention
+	// cancels out.
+	diffuse := surface.Mult(color.White.Sub(f)).Scale(1 - mat.Metallic())
+	return diffuse.Add(specular.Scale(m.Pi)).Mult(intensity).Scale(nl)
+}
+
+// Alpha is the width of the GGX distribution for a perceptual roughness. It
+// never reaches zero, which would make highlights infinitely small.
+func Alpha(roughness float64) float64 {
+	return m.Max(roughness*roughness, 1e-3)
+}
+
+// SpecularColor is the reflectance of a surface seen head on, F0. Metals
+// tint their reflections with their color, dielectrics do not.
+func SpecularColor(mat core.Material, surface color.Color) color.Color {
+	dielectric := color.White.Scale(dielectricF0)
+	metallic := mat.Metallic()
+	return dielectric.Scale(1 - metallic).Add(surface.Scale(metallic))
+}
+
+// FresnelSchlick approximates the reflectance of a surface for light arriving
+// at an angle of the given cosine with its normal.
+func FresnelSchlick(f0 color.Color, cos float64) color.Color {
+	return f0.Add(color.White.Sub(f0).Scale(m.Pow(1-cos, 5)))
+}
+
+// GGX is the density of microfacet normals at an angle of the given cosine
+// with the surface normal.
+func GGX(nh, alpha float64) float64 {
+	a2 := alpha * alpha
+	d := nh*nh*(a2-1) + 1
+	return a2 / (m.Pi * d * d)
+}
+
+// Smith is the fraction of microfacets both lit and visible, from the cosines
+// of the light and eye directions with the normal.
+func Smith(nl, nv, alpha float64) float64 {
+	k := alpha / 2
+	g1 := func(cos float64) float64 {
+		return cos / (cos*(1-k) + k)
+	}
+	return g1(nl) * g1(nv)
+}
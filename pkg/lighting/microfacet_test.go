@@ -0,0 +1,74 @@
+package lighting_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+func TestMicrofacetHeadOn(t *testing.T) {
+	normal := math.NewVector(0, 0, -1)
+	cases := []struct {
+		name      string
+		metallic  float64
+		roughness float64
+		surface   color.Color
+		expected  color.Color
+	}{
+		// 96% diffused and 4% reflected by microfacets of density 1/pi
+		{"rough dielectric", 0, 1, color.White, color.White.Scale(0.96 + 0.01)},
+		// Only the reflections, tinted by the metal
+		{"rough metal", 1, 1, color.Red, color.Red.Scale(0.25)},
+	}
+	for _, c := range cases {
+		mat := material.NewPBRMaterial().SetMetallic(c.metallic).SetRoughness(c.roughness)
+		result := lighting.Microfacet(mat, c.surface, color.White, normal, normal, normal)
+		if !result.Equal(c.expected) {
+			t.Errorf("%v: expected %v, got %v", c.name, c.expected, result)
+		}
+	}
+}
+
+func TestMicrofacetLightBehindSurface(t *testing.T) {
+	mat := material.NewPBRMaterial()
+	normal := math.NewVector(0, 0, -1)
+	result := lighting.Microfacet(mat, color.White, color.White, math.NewVector(0, 0, 1), normal, normal)
+	if !result.Equal(color.Black) {
+		t.Errorf("Expected no light from behind the surface, got %v", result)
+	}
+}
+
+func TestMicrofacetRoughnessSpreadsHighlight(t *testing.T) {
+	normal := math.NewVector(0, 1, 0)
+	light := math.NewVector(-1, 1, 0).Normalize()
+	mirror := math.NewVector(1, 1, 0).Normalize()
+	off := normal
+
+	highlight := func(roughness float64, eye math.Vector) float64 {
+		mat := material.NewPBRMaterial().SetMetallic(1).SetRoughness(roughness)
+		return lighting.Microfacet(mat, color.White, color.White, light, eye, normal).R
+	}
+	if !(highlight(0.2, mirror) > highlight(0.8, mirror)) {
+		t.Errorf("Expected smooth metal to have a brighter highlight in the mirror direction")
+	}
+	if !(highlight(0.2, off) < highlight(0.8, off)) {
+		t.Errorf("Expected rough metal to spread its highlight away from the mirror direction")
+	}
+}
+
+func TestDirectUsesMaterialModel(t *testing.T) {
+	normal := math.NewVector(0, 0, -1)
+	pbr := material.NewPBRMaterial()
+	if got, expected := lighting.Direct(pbr, color.White, color.White, normal, normal, normal),
+		lighting.Microfacet(pbr, color.White, color.White, normal, normal, normal); !got.Equal(expected) {
+		t.Errorf("Expected microfacet shading %v, got %v", expected, got)
+	}
+	phong := material.NewMaterial()
+	// Diffuse 0.9 and specular 0.9, as in the Phong scenarios
+	if got := lighting.Direct(phong, color.White, color.White, normal, normal, normal); !got.Equal(color.White.Scale(1.8)) {
+		t.Errorf("Expected Phong shading to be unchanged, got %v", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/bricef/ray-tracer/pkg/component"
 	"github.com/bricef/ray-tracer/pkg/core"
 	"github.com/bricef/ray-tracer/pkg/entity"
+	"github.com/bricef/ray-tracer/pkg/math"
 )
 
 type pointLight struct {
@@ -21,8 +22,17 @@ func (p *pointLight) Intensity() color.Color {
 	return p.intensity
 }
 
+func (l *pointLight) Towards(owner core.Entity, p math.Point) (math.Vector, float64) {
+	path := owner.Position().Sub(p).AsVector()
+	return path.Normalize(), path.Magnitude()
+}
+
+func (l *pointLight) IntensityAt(owner core.Entity, p math.Point) color.Color {
+	return l.intensity
+}
+
 func (l *pointLight) Type() core.ComponentType {
-	return component.PointLight
+	return component.Light
 }
 
 func (l *pointLight) String() string {
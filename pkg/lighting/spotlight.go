@@ -0,0 +1,62 @@
+package lighting
+
+import (
+	"fmt"
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/component"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entity"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// spotLight shines a cone of light from the entity position. Rotating the
+// entity turns the axis of the cone.
+type spotLight struct {
+	intensity color.Color
+	direction math.Vector
+	angle     float64
+	falloff   float64
+}
+
+// NewSpotLight creates a spot light lighting everything within angle radians
+// of the direction. The intensity fades out smoothly over the outermost
+// falloff radians of the cone.
+func NewSpotLight(intensity color.Color, direction math.Vector, angle float64, falloff float64) core.Entity {
+	return entity.NewEntity().
+		AddComponent(&spotLight{intensity, direction.Normalize(), angle, m.Min(falloff, angle)}).
+		SetName("SpotLight")
+}
+
+func (l *spotLight) Intensity() color.Color {
+	return l.intensity
+}
+
+func (l *spotLight) Towards(owner core.Entity, p math.Point) (math.Vector, float64) {
+	path := owner.Position().Sub(p).AsVector()
+	return path.Normalize(), path.Magnitude()
+}
+
+func (l *spotLight) IntensityAt(owner core.Entity, p math.Point) color.Color {
+	axis := owner.Transform().Apply(l.direction).AsVector().Normalize()
+	toPoint := p.Sub(owner.Position()).AsVector().Normalize()
+	theta := m.Acos(m.Max(-1, m.Min(1, axis.Dot(toPoint))))
+
+	switch {
+	case theta >= l.angle:
+		return color.Black
+	case theta <= l.angle-l.falloff:
+		return l.intensity
+	}
+	x := (l.angle - theta) / l.falloff
+	return l.intensity.Scale(x * x * (3 - 2*x)) // smoothstep
+}
+
+func (l *spotLight) Type() core.ComponentType {
+	return component.Light
+}
+
+func (l *spotLight) String() string {
+	return fmt.Sprintf("SpotLight(%v, %v, %v, %v)", l.intensity, l.direction, l.angle, l.falloff)
+}
@@ -0,0 +1,1010 @@
+package loader
+
+import (
+	"fmt"
+	"io"
+	m "math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bricef/ray-tracer/pkg/camera"
+	"github.com/bricef/ray-tracer/pkg/canvas"
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/csg"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/entity"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/meshes"
+	"github.com/bricef/ray-tracer/pkg/scene"
+	"github.com/bricef/ray-tracer/pkg/shaders"
+	"gopkg.in/yaml.v3"
+)
+
+// Error is returned for any problem found in a scene description. Line refers
+// to the line in the source document where the problem was found.
+type Error struct {
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %v: %v", e.Line, e.Message)
+}
+
+func errorf(n *yaml.Node, format string, args ...interface{}) error {
+	return &Error{
+		Line:    n.Line,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+type loader struct {
+	defines map[string]*yaml.Node
+	scene   *scene.Scene
+	camera  *camera.Camera
+	// Directory relative texture paths are resolved from
+	dir string
+	// Transform definitions being expanded, outermost first
+	expanding []string
+}
+
+// LoadFile reads a scene description from a YAML file.
+func LoadFile(filename string) (*scene.Scene, *camera.Camera, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return load(f, filepath.Dir(filename))
+}
+
+// Load reads a scene description in the YAML dialect of specs/code/cover.yml
+// and returns the scene along with the camera it describes.
+// Relative texture paths are resolved from the working directory.
+func Load(r io.Reader) (*scene.Scene, *camera.Camera, error) {
+	return load(r, ".")
+}
+
+func load(r io.Reader, dir string) (*scene.Scene, *camera.Camera, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+
+	l := &loader{
+		defines: map[string]*yaml.Node{},
+		scene:   scene.NewScene(),
+		dir:     dir,
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil, errorf(&doc, "empty scene description")
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		return nil, nil, errorf(root, "scene description must be a list of items")
+	}
+
+	for _, item := range root.Content {
+		if err := l.item(item); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if l.camera == nil {
+		return nil, nil, errorf(root, "scene description has no camera")
+	}
+
+	return l.scene, l.camera, nil
+}
+
+// fields checks that n is a mapping containing only allowed keys, and returns
+// the values by key.
+func fields(n *yaml.Node, allowed ...string) (map[string]*yaml.Node, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, errorf(n, "expected a mapping")
+	}
+	fs := map[string]*yaml.Node{}
+	for i := 0; i < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		known := false
+		for _, a := range allowed {
+			if key.Value == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, errorf(key, "unknown key %q", key.Value)
+		}
+		if _, ok := fs[key.Value]; ok {
+			return nil, errorf(key, "duplicate key %q", key.Value)
+		}
+		fs[key.Value] = value
+	}
+	return fs, nil
+}
+
+func require(n *yaml.Node, fs map[string]*yaml.Node, keys ...string) error {
+	for _, k := range keys {
+		if _, ok := fs[k]; !ok {
+			return errorf(n, "missing key %q", k)
+		}
+	}
+	return nil
+}
+
+func (l *loader) item(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode || len(n.Content) < 2 {
+		return errorf(n, "expected an 'add' or 'define' item")
+	}
+	switch n.Content[0].Value {
+	case "define":
+		return l.define(n)
+	case "add":
+		e, err := l.add(n)
+		if err != nil {
+			return err
+		}
+		if e != nil {
+			l.scene.Add(e)
+		}
+		return nil
+	default:
+		return errorf(n.Content[0], "unknown item %q", n.Content[0].Value)
+	}
+}
+
+func (l *loader) define(n *yaml.Node) error {
+	fs, err := fields(n, "define", "extend", "value")
+	if err != nil {
+		return err
+	}
+	if err := require(n, fs, "value"); err != nil {
+		return err
+	}
+	name := fs["define"].Value
+	value := fs["value"]
+
+	if ext, ok := fs["extend"]; ok {
+		base, ok := l.defines[ext.Value]
+		if !ok {
+			return errorf(ext, "cannot extend undefined %q", ext.Value)
+		}
+		if base.Kind != yaml.MappingNode || value.Kind != yaml.MappingNode {
+			return errorf(ext, "only mappings can be extended")
+		}
+		merged := *value
+		merged.Content = append(append([]*yaml.Node{}, base.Content...), value.Content...)
+		value = &merged
+	}
+
+	l.defines[name] = value
+	return nil
+}
+
+func (l *loader) add(n *yaml.Node) (core.Entity, error) {
+	kind := n.Content[1]
+	switch kind.Value {
+	case "camera":
+		return nil, l.addCamera(n)
+	case "light":
+		return l.light(n)
+	case "group":
+		return l.group(n)
+	case "csg":
+		return l.csg(n)
+	case "sphere", "plane", "cube", "cylinder", "cone":
+		return l.shape(n)
+	default:
+		return nil, errorf(kind, "cannot add unknown type %q", kind.Value)
+	}
+}
+
+func (l *loader) addCamera(n *yaml.Node) error {
+	fs, err := fields(n, "add", "width", "height", "field-of-view", "from", "to", "up", "aperture", "focal-distance", "blades", "tone-map", "exposure", "gamma", "white", "integrator", "samples")
+	if err != nil {
+		return err
+	}
+	if err := require(n, fs, "width", "height", "field-of-view", "from", "to", "up"); err != nil {
+		return err
+	}
+	if l.camera != nil {
+		return errorf(n, "scene already has a camera")
+	}
+
+	width, err := decodeInt(fs["width"])
+	if err != nil {
+		return err
+	}
+	height, err := decodeInt(fs["height"])
+	if err != nil {
+		return err
+	}
+	if width <= 0 || height <= 0 {
+		return errorf(n, "camera dimensions must be positive. Got %vx%v", width, height)
+	}
+	fov, err := decodeFloat(fs["field-of-view"])
+	if err != nil {
+		return err
+	}
+	from, err := decodeTriple(fs["from"])
+	if err != nil {
+		return err
+	}
+	to, err := decodeTriple(fs["to"])
+	if err != nil {
+		return err
+	}
+	up, err := decodeTriple(fs["up"])
+	if err != nil {
+		return err
+	}
+
+	l.camera = camera.CameraFromFOV(width, height, fov).SetTransform(
+		math.ViewTransform(
+			math.NewPoint(from[0], from[1], from[2]),
+			math.NewPoint(to[0], to[1], to[2]),
+			math.NewVector(up[0], up[1], up[2]),
+		),
+	)
+	if err := l.camera.Validate(); err != nil {
+		return errorf(n, "camera cannot look from %v to %v with up %v", from, to, up)
+	}
+	if err := l.lens(fs, from, to); err != nil {
+		return err
+	}
+	if err := l.toneMap(fs); err != nil {
+		return err
+	}
+	return l.integrator(fs)
+}
+
+// integrator picks how the camera shades rays, and how many jittered samples
+// it takes of each pixel.
+func (l *loader) integrator(fs map[string]*yaml.Node) error {
+	if n, ok := fs["integrator"]; ok {
+		i, err := integrator(n)
+		if err != nil {
+			return err
+		}
+		l.camera.SetIntegrator(i)
+	}
+	if n, ok := fs["samples"]; ok {
+		samples, err := decodeInt(n)
+		if err != nil {
+			return err
+		}
+		if samples <= 0 {
+			return errorf(n, "samples must be positive. Got %v", samples)
+		}
+		l.camera.SetAntiAliasing(camera.AntiAliasing{Pattern: camera.Jittered, Samples: samples})
+	}
+	return nil
+}
+
+// toneMap sets how the camera compresses bright values when saving frames.
+func (l *loader) toneMap(fs map[string]*yaml.Node) error {
+	var t canvas.ToneMap
+	if n, ok := fs["tone-map"]; ok {
+		switch n.Value {
+		case "clamp":
+			t.Operator = canvas.Clamp
+		case "reinhard":
+			t.Operator = canvas.Reinhard
+		case "filmic":
+			t.Operator = canvas.Filmic
+		case "aces":
+			t.Operator = canvas.ACES
+		default:
+			return errorf(n, "unknown tone map %q", n.Value)
+		}
+	}
+	for _, f := range []struct {
+		key string
+		v   *float64
+	}{{"exposure", &t.Exposure}, {"gamma", &t.Gamma}, {"white", &t.White}} {
+		n, ok := fs[f.key]
+		if !ok {
+			continue
+		}
+		v, err := decodeFloat(n)
+		if err != nil {
+			return err
+		}
+		// Exposure is in stops, so negative values darken the frame
+		if v < 0 && f.key != "exposure" {
+			return errorf(n, "%v cannot be negative", f.key)
+		}
+		*f.v = v
+	}
+	l.camera.SetToneMap(t)
+	return nil
+}
+
+// lens sets up depth of field on the camera. It focuses on the point the
+// camera looks at unless told otherwise.
+func (l *loader) lens(fs map[string]*yaml.Node, from, to [3]float64) error {
+	if _, ok := fs["aperture"]; !ok {
+		for _, key := range []string{"focal-distance", "blades"} {
+			if n, ok := fs[key]; ok {
+				return errorf(n, "%v requires an aperture", key)
+			}
+		}
+		return nil
+	}
+	aperture, err := decodeFloat(fs["aperture"])
+	if err != nil {
+		return err
+	}
+	focal := m.Sqrt(m.Pow(to[0]-from[0], 2) + m.Pow(to[1]-from[1], 2) + m.Pow(to[2]-from[2], 2))
+	if n, ok := fs["focal-distance"]; ok {
+		if focal, err = decodeFloat(n); err != nil {
+			return err
+		}
+	}
+	if aperture < 0 || focal <= 0 {
+		return errorf(fs["aperture"], "camera lens needs a positive aperture and focal distance")
+	}
+	l.camera.SetLens(aperture, focal)
+	if n, ok := fs["blades"]; ok {
+		blades, err := decodeInt(n)
+		if err != nil {
+			return err
+		}
+		l.camera.SetApertureBlades(blades)
+	}
+	return nil
+}
+
+func (l *loader) light(n *yaml.Node) (core.Entity, error) {
+	fs, err := fields(n, "add", "at", "intensity", "corner", "uvec", "usteps", "vvec", "vsteps", "jitter")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fs["corner"]; ok {
+		return l.areaLight(n, fs)
+	}
+	if err := require(n, fs, "at", "intensity"); err != nil {
+		return nil, err
+	}
+	at, err := decodeTriple(fs["at"])
+	if err != nil {
+		return nil, err
+	}
+	intensity, err := decodeColor(fs["intensity"])
+	if err != nil {
+		return nil, err
+	}
+	return lighting.NewPointLight(intensity).Translate(at[0], at[1], at[2]), nil
+}
+
+func (l *loader) areaLight(n *yaml.Node, fs map[string]*yaml.Node) (core.Entity, error) {
+	if _, ok := fs["at"]; ok {
+		return nil, errorf(fs["at"], "area lights are placed with 'corner', not 'at'")
+	}
+	if err := require(n, fs, "corner", "uvec", "usteps", "vvec", "vsteps", "intensity"); err != nil {
+		return nil, err
+	}
+	corner, err := decodeTriple(fs["corner"])
+	if err != nil {
+		return nil, err
+	}
+	uvec, err := decodeTriple(fs["uvec"])
+	if err != nil {
+		return nil, err
+	}
+	vvec, err := decodeTriple(fs["vvec"])
+	if err != nil {
+		return nil, err
+	}
+	usteps, err := decodeInt(fs["usteps"])
+	if err != nil {
+		return nil, err
+	}
+	vsteps, err := decodeInt(fs["vsteps"])
+	if err != nil {
+		return nil, err
+	}
+	if usteps < 1 || vsteps < 1 {
+		return nil, errorf(n, "area lights need at least one step along each edge")
+	}
+	intensity, err := decodeColor(fs["intensity"])
+	if err != nil {
+		return nil, err
+	}
+	jitter := false
+	if n, ok := fs["jitter"]; ok {
+		if err := n.Decode(&jitter); err != nil {
+			return nil, errorf(n, "expected a boolean. Got %q", n.Value)
+		}
+	}
+	return lighting.NewAreaLight(
+		intensity,
+		math.NewPoint(corner[0], corner[1], corner[2]),
+		math.NewVector(uvec[0], uvec[1], uvec[2]), usteps,
+		math.NewVector(vvec[0], vvec[1], vvec[2]), vsteps,
+		jitter,
+	), nil
+}
+
+func (l *loader) group(n *yaml.Node) (core.Entity, error) {
+	fs, err := fields(n, "add", "children", "transform")
+	if err != nil {
+		return nil, err
+	}
+	g := entities.NewGroup()
+	if children, ok := fs["children"]; ok {
+		if children.Kind != yaml.SequenceNode {
+			return nil, errorf(children, "group children must be a list")
+		}
+		for _, c := range children.Content {
+			if c.Kind != yaml.MappingNode || len(c.Content) < 2 || c.Content[0].Value != "add" {
+				return nil, errorf(c, "group children must be 'add' items")
+			}
+			child, err := l.add(c)
+			if err != nil {
+				return nil, err
+			}
+			if child == nil || child.GetLight() != nil {
+				return nil, errorf(c, "groups can only contain shapes and groups")
+			}
+			g.AddChild(child)
+		}
+	}
+	if t, ok := fs["transform"]; ok {
+		if err := l.transform(g, t); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+func (l *loader) csg(n *yaml.Node) (core.Entity, error) {
+	fs, err := fields(n, "add", "operation", "left", "right", "transform")
+	if err != nil {
+		return nil, err
+	}
+	if err := require(n, fs, "operation", "left", "right"); err != nil {
+		return nil, err
+	}
+
+	ops := map[string]csg.Operation{
+		"union":        csg.Union,
+		"intersection": csg.Intersection,
+		"difference":   csg.Difference,
+	}
+	op, ok := ops[fs["operation"].Value]
+	if !ok {
+		return nil, errorf(fs["operation"], "unknown CSG operation %q", fs["operation"].Value)
+	}
+
+	operands := []core.Entity{}
+	for _, key := range []string{"left", "right"} {
+		c := fs[key]
+		if c.Kind != yaml.MappingNode || len(c.Content) < 2 || c.Content[0].Value != "add" {
+			return nil, errorf(c, "CSG operands must be 'add' items")
+		}
+		e, err := l.add(c)
+		if err != nil {
+			return nil, err
+		}
+		if e == nil || e.GetLight() != nil {
+			return nil, errorf(c, "CSG operands can only be shapes, groups or CSG")
+		}
+		operands = append(operands, e)
+	}
+
+	e := entities.NewCSG(op, operands[0], operands[1])
+	if t, ok := fs["transform"]; ok {
+		if err := l.transform(e, t); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (l *loader) shape(n *yaml.Node) (core.Entity, error) {
+	kind := n.Content[1].Value
+	allowed := []string{"add", "material", "transform"}
+	if kind == "cylinder" || kind == "cone" {
+		allowed = append(allowed, "min", "max", "closed")
+	}
+	fs, err := fields(n, allowed...)
+	if err != nil {
+		return nil, err
+	}
+
+	var e core.Entity
+	switch kind {
+	case "sphere":
+		e = entities.NewSphere()
+	case "plane":
+		e = entities.NewPlane()
+	case "cube":
+		e = entities.NewCube()
+	case "cylinder":
+		mesh, err := bounded(fs, meshes.CylinderMeshLimited, meshes.CylinderClosedMesh)
+		if err != nil {
+			return nil, err
+		}
+		e = entity.NewEntity().
+			AddComponent(mesh).
+			AddComponent(material.NewMaterial()).
+			SetName("Cylinder")
+	case "cone":
+		mesh, err := bounded(fs, meshes.ConeMeshLimited, meshes.ConeClosedMesh)
+		if err != nil {
+			return nil, err
+		}
+		e = entity.NewEntity().
+			AddComponent(mesh).
+			AddComponent(material.NewMaterial()).
+			SetName("Cone")
+	}
+
+	if m, ok := fs["material"]; ok {
+		mat, err := l.material(m)
+		if err != nil {
+			return nil, err
+		}
+		e.AddComponent(mat)
+	}
+	if t, ok := fs["transform"]; ok {
+		if err := l.transform(e, t); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// bounded builds a cylinder or cone mesh from optional min, max and closed
+// keys. Missing limits leave open meshes unbounded in that direction, and put
+// the caps of closed meshes at -1 and 1 as caps cannot be infinitely far.
+func bounded(fs map[string]*yaml.Node, open, closed func(float64, float64) core.Mesh) (core.Mesh, error) {
+	capped := false
+	if n, ok := fs["closed"]; ok {
+		if err := n.Decode(&capped); err != nil {
+			return nil, errorf(n, "expected a boolean. Got %q", n.Value)
+		}
+	}
+	min, max := m.Inf(-1), m.Inf(+1)
+	if capped {
+		min, max = -1.0, 1.0
+	}
+	var err error
+	if n, ok := fs["min"]; ok {
+		if min, err = decodeFloat(n); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := fs["max"]; ok {
+		if max, err = decodeFloat(n); err != nil {
+			return nil, err
+		}
+	}
+	if capped {
+		return closed(min, max), nil
+	}
+	return open(min, max), nil
+}
+
+// resolve follows a scalar reference to a definition, if any.
+func (l *loader) resolve(n *yaml.Node) (*yaml.Node, error) {
+	if n.Kind != yaml.ScalarNode {
+		return n, nil
+	}
+	v, ok := l.defines[n.Value]
+	if !ok {
+		return nil, errorf(n, "undefined reference %q", n.Value)
+	}
+	return v, nil
+}
+
+func (l *loader) material(n *yaml.Node) (core.Material, error) {
+	n, err := l.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil, errorf(n, "material must be a mapping or the name of a definition")
+	}
+
+	mat := material.NewMaterial()
+	for i := 0; i < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Value == "color" || key.Value == "emission" {
+			c, err := decodeColor(value)
+			if err != nil {
+				return nil, err
+			}
+			if key.Value == "color" {
+				mat.SetColor(c)
+			} else {
+				mat.SetEmission(c)
+			}
+			continue
+		}
+		if key.Value == "texture" || key.Value == "normal-map" {
+			s, mapping, err := l.texture(value, key.Value == "normal-map")
+			if err != nil {
+				return nil, err
+			}
+			if key.Value == "texture" {
+				mat.SetShader(shaders.UV(s, mapping))
+			} else {
+				mat.SetNormalMap(shaders.TangentNormals(s, mapping))
+			}
+			continue
+		}
+		if key.Value == "bump" {
+			bump, err := bump(value)
+			if err != nil {
+				return nil, err
+			}
+			mat.SetNormalMap(bump)
+			continue
+		}
+		if key.Value == "shadow" || key.Value == "tinted-shadow" {
+			var v bool
+			if err := value.Decode(&v); err != nil {
+				return nil, errorf(value, "expected a boolean. Got %q", value.Value)
+			}
+			if key.Value == "shadow" {
+				mat.SetCastsShadow(v)
+			} else {
+				mat.SetTintsShadow(v)
+			}
+			continue
+		}
+
+		var set func(float64) core.Material
+		switch key.Value {
+		case "ambient":
+			set = mat.SetAmbient
+		case "diffuse":
+			set = mat.SetDiffuse
+		case "specular":
+			set = mat.SetSpecular
+		case "shininess":
+			set = mat.SetShininess
+		case "reflective":
+			set = mat.SetReflective
+		case "transparency":
+			set = mat.SetTransparency
+		case "refractive-index":
+			set = mat.SetRefractiveIndex
+		case "reflection-roughness":
+			set = mat.SetReflectionRoughness
+		case "refraction-roughness":
+			set = mat.SetRefractionRoughness
+		case "emission-strength":
+			set = mat.SetEmissionStrength
+		case "metallic", "roughness":
+			// Either switches the material to the microfacet model
+			mat.SetModel(core.Microfacet)
+			set = mat.SetMetallic
+			if key.Value == "roughness" {
+				set = mat.SetRoughness
+			}
+		default:
+			return nil, errorf(key, "unknown material property %q", key.Value)
+		}
+		v, err := decodeFloat(value)
+		if err != nil {
+			return nil, err
+		}
+		set(v)
+	}
+	return mat, nil
+}
+
+// texture reads an image file to wrap around a shape. Data textures such as
+// normal maps are not decoded from sRGB.
+func (l *loader) texture(n *yaml.Node, data bool) (shaders.UVShader, shaders.UVMapping, error) {
+	fs, err := fields(n, "file", "mapping", "filter", "wrap")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := require(n, fs, "file", "mapping"); err != nil {
+		return nil, nil, err
+	}
+
+	var mapping shaders.UVMapping
+	switch fs["mapping"].Value {
+	case "spherical":
+		mapping = shaders.Spherical
+	case "planar":
+		mapping = shaders.Planar
+	case "cylindrical":
+		mapping = shaders.Cylindrical
+	case "cubic":
+		mapping = shaders.Cubic
+	default:
+		return nil, nil, errorf(fs["mapping"], "unknown texture mapping %q", fs["mapping"].Value)
+	}
+	filter := shaders.Bilinear
+	if n, ok := fs["filter"]; ok {
+		switch n.Value {
+		case "nearest":
+			filter = shaders.Nearest
+		case "bilinear":
+			filter = shaders.Bilinear
+		default:
+			return nil, nil, errorf(n, "unknown texture filter %q", n.Value)
+		}
+	}
+	wrap := shaders.Repeat
+	if n, ok := fs["wrap"]; ok {
+		switch n.Value {
+		case "repeat":
+			wrap = shaders.Repeat
+		case "clamp":
+			wrap = shaders.ClampToEdge
+		case "mirror":
+			wrap = shaders.Mirror
+		default:
+			return nil, nil, errorf(n, "unknown texture wrap mode %q", n.Value)
+		}
+	}
+
+	filename := fs["file"].Value
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(l.dir, filename)
+	}
+	load := shaders.LoadTexture
+	if data {
+		load = shaders.LoadDataTexture
+	}
+	texture, err := load(filename)
+	if err != nil {
+		return nil, nil, errorf(fs["file"], "cannot load texture: %v", err)
+	}
+	return texture.Shader(filter, wrap), mapping, nil
+}
+
+// integrator reads an integrator given by name, or as a mapping with its
+// type and settings.
+func integrator(n *yaml.Node) (scene.Integrator, error) {
+	kind := n
+	fs := map[string]*yaml.Node{}
+	if n.Kind == yaml.MappingNode {
+		var err error
+		if fs, err = fields(n, "type", "depth", "far", "samples", "distance", "max"); err != nil {
+			return nil, err
+		}
+		if err := require(n, fs, "type"); err != nil {
+			return nil, err
+		}
+		kind = fs["type"]
+	}
+	allowed := map[string][]string{
+		"whitted":           {"depth"},
+		"path-tracing":      {},
+		"normals":           {},
+		"albedo":            {},
+		"depth":             {"far"},
+		"ambient-occlusion": {"samples", "distance"},
+		"heatmap":           {"max"},
+	}
+	keys, ok := allowed[kind.Value]
+	if !ok {
+		return nil, errorf(kind, "unknown integrator %q", kind.Value)
+	}
+	settings := map[string]float64{}
+	for i := 0; n.Kind == yaml.MappingNode && i < len(n.Content); i += 2 {
+		key, v := n.Content[i].Value, n.Content[i+1]
+		if key == "type" {
+			continue
+		}
+		known := false
+		for _, k := range keys {
+			known = known || k == key
+		}
+		if !known {
+			return nil, errorf(v, "%v integrator has no %v setting", kind.Value, key)
+		}
+		f, err := decodeFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		if f < 0 {
+			return nil, errorf(v, "%v cannot be negative", key)
+		}
+		settings[key] = f
+	}
+
+	switch kind.Value {
+	case "whitted":
+		return scene.Whitted{Depth: int(settings["depth"])}, nil
+	case "path-tracing":
+		return scene.PathTracer{}, nil
+	case "normals":
+		return scene.Normals{}, nil
+	case "albedo":
+		return scene.Albedo{}, nil
+	case "depth":
+		if _, ok := settings["far"]; !ok {
+			return nil, errorf(n, "depth integrator requires a far distance")
+		}
+		return scene.Depth{Far: settings["far"]}, nil
+	case "ambient-occlusion":
+		return scene.AmbientOcclusion{Samples: int(settings["samples"]), Distance: settings["distance"]}, nil
+	}
+	return scene.Heatmap{Max: int(settings["max"])}, nil
+}
+
+// bump roughens a surface with noise.
+func bump(n *yaml.Node) (core.NormalMap, error) {
+	fs, err := fields(n, "strength", "scale")
+	if err != nil {
+		return nil, err
+	}
+	if err := require(n, fs, "strength"); err != nil {
+		return nil, err
+	}
+	strength, err := decodeFloat(fs["strength"])
+	if err != nil {
+		return nil, err
+	}
+	scale := 1.0
+	if n, ok := fs["scale"]; ok {
+		if scale, err = decodeFloat(n); err != nil {
+			return nil, err
+		}
+	}
+	return shaders.Bump(shaders.Noise(scale), strength), nil
+}
+
+// transform applies a list of transformations to an entity. Transformations
+// are listed in the order they apply to the object, so they are composed in
+// reverse.
+func (l *loader) transform(e core.Entity, n *yaml.Node) error {
+	ops, err := l.operations(n)
+	if err != nil {
+		return err
+	}
+	for i := len(ops) - 1; i >= 0; i-- {
+		if err := apply(e, ops[i]); err != nil {
+			return err
+		}
+	}
+	if _, err := e.Transform().Invert(); err != nil {
+		return errorf(n, "transform cannot be inverted. Is something scaled by 0?")
+	}
+	return nil
+}
+
+// operations flattens a transform list, expanding references to definitions.
+func (l *loader) operations(n *yaml.Node) ([]*yaml.Node, error) {
+	if n.Kind == yaml.ScalarNode {
+		for i, name := range l.expanding {
+			if name == n.Value {
+				cycle := append(append([]string{}, l.expanding[i:]...), n.Value)
+				return nil, errorf(n, "transform %q refers to itself: %s", n.Value, strings.Join(cycle, " -> "))
+			}
+		}
+		l.expanding = append(l.expanding, n.Value)
+		defer func() { l.expanding = l.expanding[:len(l.expanding)-1] }()
+	}
+	n, err := l.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	if n.Kind != yaml.SequenceNode {
+		return nil, errorf(n, "transform must be a list")
+	}
+	ops := []*yaml.Node{}
+	for _, op := range n.Content {
+		if op.Kind == yaml.ScalarNode {
+			expanded, err := l.operations(op)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, expanded...)
+			continue
+		}
+		if op.Kind != yaml.SequenceNode || len(op.Content) == 0 {
+			return nil, errorf(op, "transformation must be a list such as [ translate, 1, 2, 3 ]")
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func apply(e core.Entity, op *yaml.Node) error {
+	name := op.Content[0].Value
+	args := make([]float64, len(op.Content)-1)
+	for i, a := range op.Content[1:] {
+		v, err := decodeFloat(a)
+		if err != nil {
+			return err
+		}
+		args[i] = v
+	}
+
+	arity := map[string]int{
+		"translate": 3,
+		"scale":     3,
+		"rotate-x":  1,
+		"rotate-y":  1,
+		"rotate-z":  1,
+		"shear":     6,
+	}
+	expected, ok := arity[name]
+	if !ok {
+		return errorf(op.Content[0], "unknown transformation %q", name)
+	}
+	if len(args) != expected {
+		return errorf(op, "%v takes %v arguments. Got %v", name, expected, len(args))
+	}
+
+	switch name {
+	case "translate":
+		e.Translate(args[0], args[1], args[2])
+	case "scale":
+		e.Scale(args[0], args[1], args[2])
+	case "rotate-x":
+		e.RotateX(args[0])
+	case "rotate-y":
+		e.RotateY(args[0])
+	case "rotate-z":
+		e.RotateZ(args[0])
+	case "shear":
+		e.Shear(args[0], args[1], args[2], args[3], args[4], args[5])
+	}
+	return nil
+}
+
+func decodeFloat(n *yaml.Node) (float64, error) {
+	var v float64
+	if n.Kind != yaml.ScalarNode || n.Decode(&v) != nil {
+		return 0, errorf(n, "expected a number. Got %q", n.Value)
+	}
+	return v, nil
+}
+
+func decodeInt(n *yaml.Node) (int, error) {
+	var v int
+	if n.Kind != yaml.ScalarNode || n.Decode(&v) != nil {
+		return 0, errorf(n, "expected an integer. Got %q", n.Value)
+	}
+	return v, nil
+}
+
+func decodeTriple(n *yaml.Node) ([3]float64, error) {
+	var v [3]float64
+	if n.Kind != yaml.SequenceNode || len(n.Content) != 3 {
+		return v, errorf(n, "expected a list of three numbers")
+	}
+	for i, c := range n.Content {
+		f, err := decodeFloat(c)
+		if err != nil {
+			return v, err
+		}
+		v[i] = f
+	}
+	return v, nil
+}
+
+// decodeColor reads linear values from a list of three numbers, or an sRGB
+// color from a hex string such as "#5f9ea0".
+func decodeColor(n *yaml.Node) (color.Color, error) {
+	if n.Kind == yaml.ScalarNode && strings.HasPrefix(n.Value, "#") {
+		v, err := strconv.ParseUint(n.Value[1:], 16, 32)
+		if err != nil || len(n.Value) != 7 {
+			return color.Black, errorf(n, "expected a hex color like #rrggbb. Got %q", n.Value)
+		}
+		return color.Hex(uint32(v)), nil
+	}
+	v, err := decodeTriple(n)
+	if err != nil {
+		return color.Black, err
+	}
+	return color.New(v[0], v[1], v[2]), nil
+}
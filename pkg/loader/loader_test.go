@@ -0,0 +1,593 @@
+package loader_test
+
+import (
+	"errors"
+	"image"
+	imageColor "image/color"
+	"image/png"
+	"io/ioutil"
+	m "math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/camera"
+	"github.com/bricef/ray-tracer/pkg/canvas"
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/loader"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+func TestLoadCoverScene(t *testing.T) {
+	s, c, err := loader.LoadFile("../../specs/code/cover.yml")
+	if err != nil {
+		t.Fatalf("Failed to load cover scene: %v", err)
+	}
+
+	if c.FrameWidth != 100 || c.FrameHeight != 100 || c.FOV != 0.785 {
+		t.Errorf("Camera not loaded correctly. Got %vx%v, fov %v", c.FrameWidth, c.FrameHeight, c.FOV)
+	}
+
+	if len(s.Lights()) != 2 {
+		t.Errorf("Expected 2 lights in cover scene, got %v", len(s.Lights()))
+	}
+
+	if len(s.Entities) != 19 {
+		t.Errorf("Expected 19 entities in cover scene, got %v", len(s.Entities))
+	}
+
+	// blue-material extends white-material
+	mat := s.Entities[3].GetMaterial()
+	if !mat.Color().Equal(color.New(0.537, 0.831, 0.914)) || mat.Diffuse() != 0.7 || mat.Reflective() != 0.1 {
+		t.Errorf("Extended material not loaded correctly. Got %v with color %v", mat, mat.Color())
+	}
+}
+
+func TestLoadTransformsApplyInOrder(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: sphere
+  transform:
+    - [ rotate-y, 1.5707963267948966 ]
+    - [ translate, 1, 2, 3 ]
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	result := s.Entities[0].Transform()
+	expected := math.NewTransform().Translate(1, 2, 3).RotateY(m.Pi / 2)
+	if !result.Equal(expected) {
+		t.Errorf("Transforms not composed in order. Expected %v, got %v", expected, result)
+	}
+}
+
+func TestLoadErrorsHaveLineNumbers(t *testing.T) {
+	camera := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+`
+	type Case struct {
+		doc  string
+		line int
+	}
+	cases := []Case{
+		{camera + "- add: sphere\n  colour: [ 1, 0, 0 ]\n", 10},
+		{camera + "- add: sphere\n  material:\n    diffuse: lots\n", 11},
+		{camera + "- add: teapot\n", 9},
+		{camera + "- add: cube\n  material: missing-material\n", 10},
+		{camera + "- add: cube\n  transform:\n    - [ translate, 1, 2 ]\n", 11},
+		{camera + "- define: m\n  extend: nothing\n  value:\n    ambient: 1\n", 10},
+		{"- add: light\n  at: [ 0, 0, 0 ]\n  intensity: [ 1, 1, 1 ]\n", 1},
+		{camera + "- add: cube\n  transform:\n    - [ scale, 1, 0, 1 ]\n", 11},
+		{"- add: camera\n  width: 10\n  height: 10\n  field-of-view: 1\n  from: [ 0, 0, -5 ]\n  to: [ 0, 0, -5 ]\n  up: [ 0, 1, 0 ]\n", 1},
+		{strings.TrimSuffix(camera, "\n") + "\n  tone-map: sepia\n", 9},
+		{camera + "- define: a\n  value: [ a ]\n- add: cube\n  transform: a\n", 10},
+		{camera + "- define: a\n  value: [ b ]\n- define: b\n  value: [ [ scale, 2, 2, 2 ], a ]\n- add: cube\n  transform: a\n", 12},
+	}
+
+	for _, c := range cases {
+		_, _, err := loader.Load(strings.NewReader(c.doc))
+		var lerr *loader.Error
+		if !errors.As(err, &lerr) {
+			t.Errorf("Expected a loader error for %q. Got %v", c.doc, err)
+			continue
+		}
+		if lerr.Line != c.line {
+			t.Errorf("Error reported at wrong line. Expected %v, got %v (%v)", c.line, lerr.Line, lerr)
+		}
+	}
+}
+
+func TestLoadCSG(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: csg
+  operation: difference
+  left:
+    add: cube
+  right:
+    add: sphere
+    transform:
+      - [ scale, 1.3, 1.3, 1.3 ]
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	e := s.Entities[0]
+	if e.GetCSG() == nil || len(e.Children()) != 2 {
+		t.Errorf("Expected a CSG entity with two operands. Got %v", e)
+	}
+}
+
+func TestLoadClosedCylinderDefaultsToUnitHeight(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: cylinder
+  closed: true
+- add: cylinder
+  closed: true
+  min: 0
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	down := ray.NewRay(math.NewPoint(0, 5, 0), math.NewVector(0, -1, 0))
+	cases := []struct {
+		name     string
+		expected []float64
+	}{
+		{"no limits", []float64{4, 6}},
+		{"min only", []float64{4, 5}},
+	}
+	for i, c := range cases {
+		xs := down.Intersect(s.Entities[i]).All
+		if len(xs) != len(c.expected) {
+			t.Errorf("%v: expected caps at t=%v, got %v intersections", c.name, c.expected, len(xs))
+			continue
+		}
+		ts := []float64{}
+		for _, x := range xs {
+			ts = append(ts, x.T)
+		}
+		sort.Float64s(ts)
+		for j, v := range ts {
+			if v != c.expected[j] {
+				t.Errorf("%v: expected cap at t=%v, got %v", c.name, c.expected[j], v)
+			}
+		}
+	}
+}
+
+func TestLoadAreaLight(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: light
+  corner: [ -1, 2, 4 ]
+  uvec: [ 2, 0, 0 ]
+  vvec: [ 0, 2, 0 ]
+  usteps: 10
+  vsteps: 10
+  jitter: true
+  intensity: [ 1.5, 1.5, 1.5 ]
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	l := s.Lights()[0]
+	area, ok := l.GetLight().(core.AreaLight)
+	if !ok || len(area.Samples()) != 100 {
+		t.Fatalf("Expected an area light with 100 samples. Got %v", l.GetLight())
+	}
+	if !l.Position().Equal(math.NewPoint(0, 3, 4)) {
+		t.Errorf("Expected area light centered at (0, 3, 4). Got %v", l.Position())
+	}
+}
+
+func TestLoadHexColors(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: sphere
+  material:
+    color: "#5f9ea0"
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	m := s.Entities[0].GetMaterial().(*material.Material)
+	if expected := color.Hex(0x5f9ea0); !m.Color().Equal(expected) {
+		t.Errorf("Expected hex color decoded from sRGB to %v. Got %v", expected, m.Color())
+	}
+
+	_, _, err = loader.Load(strings.NewReader(strings.Replace(doc, "#5f9ea0", "#5f9ea", 1)))
+	var lerr *loader.Error
+	if !errors.As(err, &lerr) || lerr.Line != 11 {
+		t.Errorf("Expected malformed hex color to fail on line 11. Got %v", err)
+	}
+}
+
+func TestLoadTexture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, imageColor.NRGBA{255, 0, 0, 255})
+	img.Set(1, 0, imageColor.NRGBA{0, 0, 255, 255})
+	f, err := os.Create(filepath.Join(dir, "stripes.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, img)
+	f.Close()
+
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: plane
+  material:
+    texture:
+      file: stripes.png
+      mapping: planar
+      filter: nearest
+`
+	filename := filepath.Join(dir, "scene.yml")
+	if err := ioutil.WriteFile(filename, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, _, err := loader.LoadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	mat := s.Entities[0].GetMaterial()
+	if c := mat.ColorAt(math.NewPoint(0.25, 0, 0.5)); !c.Equal(color.Red) {
+		t.Errorf("Expected texture on the left half of the unit square. Got %v", c)
+	}
+	if c := mat.ColorAt(math.NewPoint(1.75, 0, 0.5)); !c.Equal(color.Blue) {
+		t.Errorf("Expected texture to repeat. Got %v", c)
+	}
+
+	_, _, err = loader.Load(strings.NewReader(doc))
+	var lerr *loader.Error
+	if !errors.As(err, &lerr) || lerr.Line != 12 {
+		t.Errorf("Expected missing texture to fail on line 12. Got %v", err)
+	}
+}
+
+func TestLoadNormalPerturbation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Every texel tilts the normal towards increasing u
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, imageColor.NRGBA{255, 128, 128, 255})
+	f, err := os.Create(filepath.Join(dir, "normals.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, img)
+	f.Close()
+
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: plane
+  material:
+    normal-map:
+      file: normals.png
+      mapping: planar
+- add: sphere
+  material:
+    bump:
+      strength: 0.5
+      scale: 3
+`
+	filename := filepath.Join(dir, "scene.yml")
+	if err := ioutil.WriteFile(filename, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, _, err := loader.LoadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	plane := s.Entities[0]
+	up := math.NewVector(0, 1, 0)
+	if n := plane.GetMaterial().NormalOn(plane, math.NewPoint(0.3, 0, 0.3), up); n.X() <= 0 || n.Y() <= 0 {
+		t.Errorf("Expected plane normal tilted towards +x. Got %v", n)
+	}
+	sphere := s.Entities[1]
+	back := math.NewVector(0, 0, -1)
+	if n := sphere.GetMaterial().NormalOn(sphere, math.NewPoint(0, 0, -1), back); n.Equal(back) {
+		t.Errorf("Expected bumps on the sphere")
+	}
+}
+
+func TestLoadShadowMaterialFlags(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: sphere
+  material:
+    transparency: 0.9
+    tinted-shadow: true
+- add: plane
+  material:
+    shadow: false
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	glass := s.Entities[0].GetMaterial()
+	if !glass.CastsShadow() || !glass.TintsShadow() {
+		t.Errorf("Expected sphere to cast a tinted shadow")
+	}
+	if s.Entities[1].GetMaterial().CastsShadow() {
+		t.Errorf("Expected plane not to cast shadows")
+	}
+}
+
+func TestLoadMicrofacetMaterial(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: sphere
+  material:
+    metallic: 1
+    roughness: 0.2
+- add: plane
+  material:
+    diffuse: 0.5
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+
+	metal := s.Entities[0].GetMaterial()
+	if metal.Model() != core.Microfacet || metal.Metallic() != 1 || metal.Roughness() != 0.2 {
+		t.Errorf("Expected a rough metal, got model %v, metallic %v, roughness %v", metal.Model(), metal.Metallic(), metal.Roughness())
+	}
+	if s.Entities[1].GetMaterial().Model() != core.Phong {
+		t.Errorf("Expected plane to keep the Phong model")
+	}
+}
+
+func TestLoadEmissiveMaterial(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: cube
+  material:
+    emission: [ 1, 0.5, 0.25 ]
+    emission-strength: 4
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	expected := color.New(4, 2, 1)
+	if e := s.Entities[0].GetMaterial().Emission(); !e.Equal(expected) {
+		t.Errorf("Expected emission %v, got %v", expected, e)
+	}
+	if len(s.Emitters()) != 1 {
+		t.Errorf("Expected the glowing cube to light the scene")
+	}
+}
+
+func TestLoadRoughReflectionAndRefraction(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+- add: sphere
+  material:
+    reflective: 0.8
+    reflection-roughness: 0.3
+    transparency: 0.9
+    refraction-roughness: 0.1
+`
+	s, _, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	mat := s.Entities[0].GetMaterial()
+	if mat.ReflectionRoughness() != 0.3 || mat.RefractionRoughness() != 0.1 {
+		t.Errorf("Expected reflection and refraction roughness of 0.3 and 0.1, got %v and %v", mat.ReflectionRoughness(), mat.RefractionRoughness())
+	}
+}
+
+func TestLoadCameraLens(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 3, -4 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+  aperture: 0.2
+  blades: 6
+`
+	_, c, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	if c.Aperture != 0.2 || c.FocalDistance != 5 || c.ApertureBlades != 6 {
+		t.Errorf("Expected lens focused on the target. Got aperture %v, focal distance %v, %v blades", c.Aperture, c.FocalDistance, c.ApertureBlades)
+	}
+}
+
+func TestLoadCameraIntegrator(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+  integrator: path-tracing
+  samples: 8
+`
+	_, c, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	if c.Integrator != (scene.PathTracer{}) {
+		t.Errorf("Expected path tracing integrator. Got %v", c.Integrator)
+	}
+	if c.AntiAliasing == nil || c.AntiAliasing.Pattern != camera.Jittered || c.AntiAliasing.Samples != 8 {
+		t.Errorf("Expected 8x8 jittered samples. Got %v", c.AntiAliasing)
+	}
+}
+
+func TestLoadDebugIntegrators(t *testing.T) {
+	camera := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+  integrator:
+`
+	tests := []struct {
+		doc      string
+		expected scene.Integrator
+	}{
+		{"    type: depth\n    far: 20\n", scene.Depth{Far: 20}},
+		{"    type: ambient-occlusion\n    samples: 4\n", scene.AmbientOcclusion{Samples: 4}},
+		{"    type: whitted\n    depth: 2\n", scene.Whitted{Depth: 2}},
+		{"    type: normals\n", scene.Normals{}},
+	}
+	for _, test := range tests {
+		_, c, err := loader.Load(strings.NewReader(camera + test.doc))
+		if err != nil {
+			t.Errorf("Failed to load %q: %v", test.doc, err)
+			continue
+		}
+		if c.Integrator != test.expected {
+			t.Errorf("Expected integrator %v. Got %v", test.expected, c.Integrator)
+		}
+	}
+
+	for _, doc := range []string{
+		"    type: depth\n",
+		"    type: normals\n    far: 2\n",
+		"    type: wireframe\n",
+	} {
+		if _, _, err := loader.Load(strings.NewReader(camera + doc)); err == nil {
+			t.Errorf("Expected %q to fail", doc)
+		}
+	}
+}
+
+func TestLoadCameraToneMap(t *testing.T) {
+	doc := `
+- add: camera
+  width: 10
+  height: 10
+  field-of-view: 1
+  from: [ 0, 0, -5 ]
+  to: [ 0, 0, 0 ]
+  up: [ 0, 1, 0 ]
+  tone-map: aces
+  exposure: -1
+  gamma: 2.2
+`
+	_, c, err := loader.Load(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Failed to load scene: %v", err)
+	}
+	expected := canvas.ToneMap{Operator: canvas.ACES, Exposure: -1, Gamma: 2.2}
+	if c.ToneMap != expected {
+		t.Errorf("Expected tone map %v. Got %v", expected, c.ToneMap)
+	}
+}
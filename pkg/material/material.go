@@ -10,30 +10,54 @@ import (
 )
 
 type Material struct {
-	color           color.Color
-	ambient         float64
-	diffuse         float64
-	specular        float64
-	shininess       float64
-	shader          core.Shader
-	reflective      float64
-	transparency    float64
-	refractiveIndex float64
+	color               color.Color
+	ambient             float64
+	diffuse             float64
+	specular            float64
+	shininess           float64
+	shader              core.Shader
+	normalMap           core.NormalMap
+	reflective          float64
+	transparency        float64
+	refractiveIndex     float64
+	castsShadow         bool
+	tintsShadow         bool
+	model               core.ShadingModel
+	metallic            float64
+	roughness           float64
+	emission            color.Color
+	emissionStrength    float64
+	reflectionRoughness float64
+	refractionRoughness float64
 }
 
 func NewMaterial() *Material {
 	return &Material{
-		color:           color.New(1, 1, 1),
-		ambient:         0.1,
-		diffuse:         0.9,
-		specular:        0.9,
-		shininess:       200.0,
-		shader:          nil,
-		reflective:      0.0,
-		refractiveIndex: 1.0,
+		color:            color.New(1, 1, 1),
+		ambient:          0.1,
+		diffuse:          0.9,
+		specular:         0.9,
+		shininess:        200.0,
+		shader:           nil,
+		reflective:       0.0,
+		refractiveIndex:  1.0,
+		castsShadow:      true,
+		tintsShadow:      false,
+		model:            core.Phong,
+		metallic:         0.0,
+		roughness:        0.5,
+		emission:         color.Black,
+		emissionStrength: 1.0,
 	}
 }
 
+// NewPBRMaterial is a dielectric material shaded with the microfacet model.
+func NewPBRMaterial() *Material {
+	m := NewMaterial()
+	m.model = core.Microfacet
+	return m
+}
+
 func (m *Material) String() string {
 	return fmt.Sprintf("Material(reflective: %v, refractive: %v, ambient: %v)", m.reflective, m.refractiveIndex, m.ambient)
 }
@@ -42,8 +66,16 @@ func (m *Material) Type() core.ComponentType {
 	return component.Material
 }
 
+// Equal compares every property of the materials. Shaders and normal maps
+// are functions, which cannot be compared, and emission is compared as the
+// light given off, scaled by its strength.
 func (m *Material) Equal(o core.Material) bool {
-	return m.color.Equal(o.Color()) && m.ambient == o.Ambient() && m.diffuse == o.Diffuse() && m.specular == o.Specular() && m.shininess == o.Shininess()
+	return m.color.Equal(o.Color()) && m.ambient == o.Ambient() && m.diffuse == o.Diffuse() && m.specular == o.Specular() && m.shininess == o.Shininess() &&
+		m.reflective == o.Reflective() && m.transparency == o.Transparency() && m.refractiveIndex == o.RefractiveIndex() &&
+		m.castsShadow == o.CastsShadow() && m.tintsShadow == o.TintsShadow() &&
+		m.model == o.Model() && m.metallic == o.Metallic() && m.roughness == o.Roughness() &&
+		m.Emission().Equal(o.Emission()) &&
+		m.reflectionRoughness == o.ReflectionRoughness() && m.refractionRoughness == o.RefractionRoughness()
 }
 
 func (m *Material) SetAmbient(v float64) core.Material {
@@ -76,6 +108,46 @@ func (m *Material) SetShader(s core.Shader) core.Material {
 	return m
 }
 
+func (m *Material) SetNormalMap(n core.NormalMap) core.Material {
+	m.normalMap = n
+	return m
+}
+
+func (m *Material) SetModel(model core.ShadingModel) core.Material {
+	m.model = model
+	return m
+}
+
+func (m *Material) SetMetallic(v float64) core.Material {
+	m.metallic = v
+	return m
+}
+
+func (m *Material) SetRoughness(v float64) core.Material {
+	m.roughness = v
+	return m
+}
+
+func (m *Material) SetEmission(c color.Color) core.Material {
+	m.emission = c
+	return m
+}
+
+func (m *Material) SetEmissionStrength(v float64) core.Material {
+	m.emissionStrength = v
+	return m
+}
+
+func (m *Material) SetReflectionRoughness(v float64) core.Material {
+	m.reflectionRoughness = v
+	return m
+}
+
+func (m *Material) SetRefractionRoughness(v float64) core.Material {
+	m.refractionRoughness = v
+	return m
+}
+
 func (m *Material) SetReflective(v float64) core.Material {
 	m.reflective = v
 	return m
@@ -91,6 +163,16 @@ func (m *Material) SetRefractiveIndex(v float64) core.Material {
 	return m
 }
 
+func (m *Material) SetCastsShadow(v bool) core.Material {
+	m.castsShadow = v
+	return m
+}
+
+func (m *Material) SetTintsShadow(v bool) core.Material {
+	m.tintsShadow = v
+	return m
+}
+
 func (m *Material) ColorAt(p math.Point) color.Color {
 	if m.shader != nil {
 		return m.shader(p)
@@ -103,6 +185,15 @@ func (m *Material) ColorOn(e core.Entity, worldPoint math.Point) color.Color {
 	return m.ColorAt(objectPoint)
 }
 
+func (m *Material) NormalOn(e core.Entity, worldPoint math.Point, worldNormal math.Vector) math.Vector {
+	if m.normalMap == nil || e.GetMesh() == nil {
+		return worldNormal
+	}
+	objectPoint := e.WorldPointToObjectPoint(worldPoint)
+	objectNormal := m.normalMap(objectPoint, e.GetMesh().Normal(objectPoint)).Normalize()
+	return e.ObjectNormalToWorldNormal(objectNormal)
+}
+
 func (m *Material) Color() color.Color {
 	return m.color
 }
@@ -127,3 +218,27 @@ func (m *Material) Transparency() float64 {
 func (m *Material) RefractiveIndex() float64 {
 	return m.refractiveIndex
 }
+func (m *Material) ReflectionRoughness() float64 {
+	return m.reflectionRoughness
+}
+func (m *Material) RefractionRoughness() float64 {
+	return m.refractionRoughness
+}
+func (m *Material) Model() core.ShadingModel {
+	return m.model
+}
+func (m *Material) Metallic() float64 {
+	return m.metallic
+}
+func (m *Material) Roughness() float64 {
+	return m.roughness
+}
+func (m *Material) Emission() color.Color {
+	return m.emission.Scale(m.emissionStrength)
+}
+func (m *Material) CastsShadow() bool {
+	return m.castsShadow
+}
+func (m *Material) TintsShadow() bool {
+	return m.tintsShadow
+}
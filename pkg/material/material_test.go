@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
 	"github.com/bricef/ray-tracer/pkg/entities"
 	"github.com/bricef/ray-tracer/pkg/lighting"
 	"github.com/bricef/ray-tracer/pkg/material"
@@ -20,6 +21,38 @@ func TestDefaultMaterial(t *testing.T) {
 
 }
 
+func TestMaterialEqualComparesEveryProperty(t *testing.T) {
+	changes := map[string]func(core.Material) core.Material{
+		"color":                func(m core.Material) core.Material { return m.SetColor(color.Red) },
+		"ambient":              func(m core.Material) core.Material { return m.SetAmbient(0.5) },
+		"diffuse":              func(m core.Material) core.Material { return m.SetDiffuse(0.5) },
+		"specular":             func(m core.Material) core.Material { return m.SetSpecular(0.5) },
+		"shininess":            func(m core.Material) core.Material { return m.SetShininess(10) },
+		"reflective":           func(m core.Material) core.Material { return m.SetReflective(0.5) },
+		"transparency":         func(m core.Material) core.Material { return m.SetTransparency(0.5) },
+		"refractive index":     func(m core.Material) core.Material { return m.SetRefractiveIndex(1.5) },
+		"casts shadow":         func(m core.Material) core.Material { return m.SetCastsShadow(false) },
+		"tints shadow":         func(m core.Material) core.Material { return m.SetTintsShadow(true) },
+		"model":                func(m core.Material) core.Material { return m.SetModel(core.Microfacet) },
+		"metallic":             func(m core.Material) core.Material { return m.SetMetallic(1) },
+		"roughness":            func(m core.Material) core.Material { return m.SetRoughness(0.1) },
+		"emission":             func(m core.Material) core.Material { return m.SetEmission(color.Red) },
+		"emission strength":    func(m core.Material) core.Material { return m.SetEmissionStrength(2) },
+		"reflection roughness": func(m core.Material) core.Material { return m.SetReflectionRoughness(0.3) },
+		"refraction roughness": func(m core.Material) core.Material { return m.SetRefractionRoughness(0.3) },
+	}
+	// Glowing, so that the emission strength shows
+	glowing := func() core.Material { return material.NewMaterial().SetEmission(color.White) }
+	for property, change := range changes {
+		if glowing().Equal(change(glowing())) {
+			t.Errorf("Expected materials differing in %v not to be equal", property)
+		}
+	}
+	if !glowing().Equal(glowing()) {
+		t.Errorf("Expected identical materials to be equal")
+	}
+}
+
 func TestMaterialLigthingWithTexture(t *testing.T) {
 	shader := shaders.Stripes(
 		shaders.Pigment(color.White),
@@ -37,8 +70,8 @@ func TestMaterialLigthingWithTexture(t *testing.T) {
 	eyev := math.NewVector(0, 0, -1)
 	normalv := math.NewVector(0, 0, -1)
 
-	c1 := lighting.Phong(mat, light, math.NewPoint(0.9, 0, 0), eyev, normalv)
-	c2 := lighting.Phong(mat, light, math.NewPoint(1.1, 0, 0), eyev, normalv)
+	c1, _ := lighting.Phong(mat, light, math.NewPoint(0.9, 0, 0), eyev, normalv)
+	c2, _ := lighting.Phong(mat, light, math.NewPoint(1.1, 0, 0), eyev, normalv)
 
 	if !c1.Equal(color.White) || !c2.Equal(color.Black) {
 		t.Errorf("Failed to render a striped texture.")
@@ -61,7 +94,10 @@ func TestShaderWithObjectTransform(t *testing.T) {
 
 func TestShaderWithShaderTransform(t *testing.T) {
 	shaderTransform := math.Scale(2, 2, 2)
-	testShader := shaders.With(shaderTransform, shaders.Test())
+	testShader, err := shaders.With(shaderTransform, shaders.Test())
+	if err != nil {
+		t.Fatal(err)
+	}
 	mat := material.NewMaterial().SetShader(testShader)
 
 	e := entities.NewSphere().AddComponent(mat)
@@ -75,7 +111,10 @@ func TestShaderWithShaderTransform(t *testing.T) {
 
 func TestShaderWithShaderAndObjectTransform(t *testing.T) {
 	shaderTransform := math.Translate(0.5, 1, 1.5)
-	testShader := shaders.With(shaderTransform, shaders.Test())
+	testShader, err := shaders.With(shaderTransform, shaders.Test())
+	if err != nil {
+		t.Fatal(err)
+	}
 	mat := material.NewMaterial().SetShader(testShader)
 
 	e := entities.NewSphere().AddComponent(mat).Scale(2, 2, 2)
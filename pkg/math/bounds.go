@@ -0,0 +1,129 @@
+package math
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+// Bounds is an axis-aligned bounding box. Unbounded shapes use infinite
+// coordinates, and a box with Min greater than Max is empty.
+type Bounds struct {
+	Min Point
+	Max Point
+}
+
+func NewBounds(min Point, max Point) Bounds {
+	return Bounds{min, max}
+}
+
+func EmptyBounds() Bounds {
+	inf := math.Inf(+1)
+	return Bounds{NewPoint(inf, inf, inf), NewPoint(-inf, -inf, -inf)}
+}
+
+func InfiniteBounds() Bounds {
+	inf := math.Inf(+1)
+	return Bounds{NewPoint(-inf, -inf, -inf), NewPoint(inf, inf, inf)}
+}
+
+func (b Bounds) IsEmpty() bool {
+	return b.Min.X() > b.Max.X() || b.Min.Y() > b.Max.Y() || b.Min.Z() > b.Max.Z()
+}
+
+func (b Bounds) IsInfinite() bool {
+	for _, v := range []float64{b.Min.X(), b.Min.Y(), b.Min.Z(), b.Max.X(), b.Max.Y(), b.Max.Z()} {
+		if math.IsInf(v, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b Bounds) Add(p Point) Bounds {
+	return Bounds{
+		NewPoint(math.Min(b.Min.X(), p.X()), math.Min(b.Min.Y(), p.Y()), math.Min(b.Min.Z(), p.Z())),
+		NewPoint(math.Max(b.Max.X(), p.X()), math.Max(b.Max.Y(), p.Y()), math.Max(b.Max.Z(), p.Z())),
+	}
+}
+
+func (b Bounds) Merge(o Bounds) Bounds {
+	if o.IsEmpty() {
+		return b
+	}
+	return b.Add(o.Min).Add(o.Max)
+}
+
+func (b Bounds) Contains(p Point) bool {
+	return b.Min.X() <= p.X() && p.X() <= b.Max.X() &&
+		b.Min.Y() <= p.Y() && p.Y() <= b.Max.Y() &&
+		b.Min.Z() <= p.Z() && p.Z() <= b.Max.Z()
+}
+
+func (b Bounds) Center() Point {
+	return NewPoint(
+		(b.Min.X()+b.Max.X())/2,
+		(b.Min.Y()+b.Max.Y())/2,
+		(b.Min.Z()+b.Max.Z())/2,
+	)
+}
+
+// Transform returns the bounds of the transformed box. Infinite boxes stay
+// infinite, as their corners cannot be transformed.
+func (b Bounds) Transform(t Transform) Bounds {
+	if b.IsEmpty() {
+		return b
+	}
+	if b.IsInfinite() {
+		return InfiniteBounds()
+	}
+	result := EmptyBounds()
+	for _, x := range []float64{b.Min.X(), b.Max.X()} {
+		for _, y := range []float64{b.Min.Y(), b.Max.Y()} {
+			for _, z := range []float64{b.Min.Z(), b.Max.Z()} {
+				result = result.Add(t.Apply(NewPoint(x, y, z)).AsPoint())
+			}
+		}
+	}
+	return result
+}
+
+// Intersects reports whether the line through origin along direction crosses
+// the box. Intersections behind the origin count, as callers need every
+// intersection along the ray.
+func (b Bounds) Intersects(origin Point, direction Vector) bool {
+	if b.IsEmpty() {
+		return false
+	}
+	tmin, tmax := math.Inf(-1), math.Inf(+1)
+	axes := [][4]float64{
+		{origin.X(), direction.X(), b.Min.X(), b.Max.X()},
+		{origin.Y(), direction.Y(), b.Min.Y(), b.Max.Y()},
+		{origin.Z(), direction.Z(), b.Min.Z(), b.Max.Z()},
+	}
+	for _, a := range axes {
+		o, d, lo, hi := a[0], a[1], a[2], a[3]
+		if math.Abs(d) < utils.Epsilon {
+			if o < lo-utils.Epsilon || o > hi+utils.Epsilon {
+				return false
+			}
+			continue
+		}
+		t1 := (lo - o) / d
+		t2 := (hi - o) / d
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tmin = math.Max(tmin, t1)
+		tmax = math.Min(tmax, t2)
+		if tmin > tmax+utils.Epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+func (b Bounds) String() string {
+	return fmt.Sprintf("Bounds(%v -> %v)", b.Min, b.Max)
+}
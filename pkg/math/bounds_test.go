@@ -0,0 +1,91 @@
+package math_test
+
+import (
+	m "math"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+func TestEmptyBounds(t *testing.T) {
+	b := math.EmptyBounds()
+	if !b.IsEmpty() {
+		t.Errorf("Expected empty bounds to be empty. Got %v", b)
+	}
+	if b.Intersects(math.NewPoint(0, 0, 0), math.NewVector(0, 0, 1)) {
+		t.Errorf("Nothing should intersect empty bounds")
+	}
+}
+
+func TestAddingPointsToBounds(t *testing.T) {
+	b := math.EmptyBounds().
+		Add(math.NewPoint(-5, 2, 0)).
+		Add(math.NewPoint(7, 0, -3))
+
+	if !b.Min.Equal(math.NewPoint(-5, 0, -3)) || !b.Max.Equal(math.NewPoint(7, 2, 0)) {
+		t.Errorf("Failed to grow bounds with points. Got %v", b)
+	}
+}
+
+func TestMergingBounds(t *testing.T) {
+	a := math.NewBounds(math.NewPoint(-5, -2, 0), math.NewPoint(7, 4, 4))
+	b := math.NewBounds(math.NewPoint(8, -7, -2), math.NewPoint(14, 2, 8))
+	result := a.Merge(b)
+
+	if !result.Min.Equal(math.NewPoint(-5, -7, -2)) || !result.Max.Equal(math.NewPoint(14, 4, 8)) {
+		t.Errorf("Failed to merge bounds. Got %v", result)
+	}
+}
+
+func TestTransformingBounds(t *testing.T) {
+	b := math.NewBounds(math.NewPoint(-1, -1, -1), math.NewPoint(1, 1, 1))
+	result := b.Transform(math.RotateX(m.Pi / 4).RotateY(m.Pi / 4))
+
+	if !result.Min.Equal(math.NewPoint(-1.41421, -1.70711, -1.70711)) || !result.Max.Equal(math.NewPoint(1.41421, 1.70711, 1.70711)) {
+		t.Errorf("Failed to transform bounds. Got %v", result)
+	}
+
+	if !math.InfiniteBounds().Transform(math.Translate(1, 0, 0)).IsInfinite() {
+		t.Errorf("Transformed infinite bounds should remain infinite")
+	}
+}
+
+func TestRayIntersectsBounds(t *testing.T) {
+	b := math.NewBounds(math.NewPoint(5, -2, 0), math.NewPoint(11, 4, 7))
+
+	type Case struct {
+		origin    math.Point
+		direction math.Vector
+		expected  bool
+	}
+	cases := []Case{
+		{math.NewPoint(15, 1, 2), math.NewVector(-1, 0, 0), true},
+		{math.NewPoint(-5, -1, 4), math.NewVector(1, 0, 0), true},
+		{math.NewPoint(7, 6, 5), math.NewVector(0, -1, 0), true},
+		{math.NewPoint(9, -5, 6), math.NewVector(0, 1, 0), true},
+		{math.NewPoint(8, 2, 12), math.NewVector(0, 0, -1), true},
+		{math.NewPoint(6, 0, -5), math.NewVector(0, 0, 1), true},
+		{math.NewPoint(8, 1, 3.5), math.NewVector(0, 0, 1), true},
+		{math.NewPoint(9, -1, -8), math.NewVector(2, 4, 6), false},
+		{math.NewPoint(8, 3, -4), math.NewVector(6, 2, 4), false},
+		{math.NewPoint(9, -1, -2), math.NewVector(4, 6, 2), false},
+		{math.NewPoint(4, 0, 9), math.NewVector(0, 0, -1), false},
+		{math.NewPoint(8, 6, -1), math.NewVector(0, -1, 0), false},
+		{math.NewPoint(12, 5, 4), math.NewVector(-1, 0, 0), false},
+	}
+
+	for _, c := range cases {
+		result := b.Intersects(c.origin, c.direction.Normalize())
+		if result != c.expected {
+			t.Errorf("Ray %v -> %v intersecting %v. Expected %v, got %v", c.origin, c.direction, b, c.expected, result)
+		}
+	}
+
+	plane := math.NewBounds(math.NewPoint(m.Inf(-1), 0, m.Inf(-1)), math.NewPoint(m.Inf(+1), 0, m.Inf(+1)))
+	if !plane.Intersects(math.NewPoint(0, 1, 0), math.NewVector(0, -1, 0)) {
+		t.Errorf("Ray should intersect infinite flat bounds")
+	}
+	if plane.Intersects(math.NewPoint(0, 1, 0), math.NewVector(1, 0, 0)) {
+		t.Errorf("Parallel ray should miss infinite flat bounds")
+	}
+}
@@ -229,14 +229,24 @@ func (m Matrix) Set(i, j int, v float64) error {
 
 }
 
+// NonInvertibleError is returned when inverting a matrix with a zero
+// determinant, such as a transform scaling an axis to nothing.
+type NonInvertibleError struct {
+	Matrix Matrix
+}
+
+func (e *NonInvertibleError) Error() string {
+	return fmt.Sprintf("trying to invert a non invertible matrix %v", e.Matrix)
+}
+
 func (m Matrix) Inverse() (Matrix, error) {
 	det, err := m.Determinant()
 	if err != nil {
 		return Matrix{}, err
 	}
 
-	if det == 0 {
-		return Matrix{}, fmt.Errorf("trying to invert a non invertible matrix %v", m)
+	if det == 0 || math.IsNaN(det) {
+		return Matrix{}, &NonInvertibleError{m}
 	}
 
 	inverse := Zero(m.Rows, m.Columns)
@@ -47,10 +47,25 @@ func (t MatrixTransform) Apply(q Quaternion) Quaternion {
 	return NewQuaternion(x, y, z, w)
 }
 
+// Inverse is Invert for transforms known to be invertible, as checked by
+// Scene.Build and Camera.Validate before rendering. It panics with a *NonInvertibleError
+// otherwise, rather than quietly rendering garbage.
 func (t MatrixTransform) Inverse() Transform {
-	m, _ := t.Matrix.Inverse()
+	inverse, err := t.Invert()
+	if err != nil {
+		panic(err)
+	}
+	return inverse
+}
+
+// Invert returns the inverse transform, or a *NonInvertibleError.
+func (t MatrixTransform) Invert() (Transform, error) {
+	m, err := t.Matrix.Inverse()
+	if err != nil {
+		return nil, err
+	}
 	t.Matrix = m
-	return t
+	return t, nil
 }
 
 func (t MatrixTransform) Transpose() Transform {
@@ -1,6 +1,7 @@
 package math_test
 
 import (
+	"errors"
 	m "math"
 	"testing"
 
@@ -148,3 +149,27 @@ func TestCases(t *testing.T) {
 		}
 	}
 }
+
+func TestInvertingSingularTransform(t *testing.T) {
+	inverse, err := math.NewTransform().Translate(1, 2, 3).Invert()
+	if err != nil || !inverse.Apply(math.NewPoint(1, 2, 3)).Equal(math.NewPoint(0, 0, 0)) {
+		t.Errorf("Failed to invert translation. Got %v (%v)", inverse, err)
+	}
+
+	_, err = math.NewTransform().Scale(1, 0, 1).Invert()
+	var ierr *math.NonInvertibleError
+	if !errors.As(err, &ierr) {
+		t.Errorf("Expected a non invertible error for a flattening transform. Got %v", err)
+	}
+}
+
+func TestInverseOfSingularTransformPanics(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		var ierr *math.NonInvertibleError
+		if !errors.As(err, &ierr) {
+			t.Errorf("Expected a non invertible error panic. Got %v", err)
+		}
+	}()
+	math.NewTransform().Scale(1, 0, 1).Inverse()
+}
@@ -38,6 +38,7 @@ type Transform interface {
 	Translate(x float64, y float64, z float64) Transform
 	Apply(Quaternion) Quaternion
 	Inverse() Transform
+	Invert() (Transform, error)
 	Transpose() Transform
 	Scale(x float64, y float64, z float64) Transform
 	RotateX(r float64) Transform
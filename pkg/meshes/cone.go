@@ -0,0 +1,133 @@
+package meshes
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/component"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+// Double-napped cone around the Y axis, with its apex at the origin and a
+// radius of |y| at height y.
+type cone struct {
+	min    float64
+	max    float64
+	capped bool
+}
+
+func ConeMesh() core.Mesh {
+	return &cone{m.Inf(-1), m.Inf(+1), false}
+}
+
+func ConeMeshLimited(min float64, max float64) core.Mesh {
+	return &cone{min, max, false}
+}
+
+func ConeClosedMesh(min, max float64) core.Mesh {
+	return &cone{min, max, true}
+}
+
+func (c *cone) Type() core.ComponentType {
+	return component.Mesh
+}
+
+func (c *cone) checkCap(r core.Ray, t float64, radius float64) bool {
+	x := r.Origin().X() + t*r.Direction().X()
+	z := r.Origin().Z() + t*r.Direction().Z()
+	return (x*x + z*z) <= radius*radius
+}
+
+func (c *cone) intersectCaps(r core.Ray) []float64 {
+	if !c.capped || utils.AlmostEqual(r.Direction().Y(), 0) {
+		return []float64{}
+	}
+	ts := []float64{}
+
+	t1 := (c.min - r.Origin().Y()) / r.Direction().Y()
+	if c.checkCap(r, t1, m.Abs(c.min)) {
+		ts = append(ts, t1)
+	}
+
+	t2 := (c.max - r.Origin().Y()) / r.Direction().Y()
+	if c.checkCap(r, t2, m.Abs(c.max)) {
+		ts = append(ts, t2)
+	}
+
+	return ts
+}
+
+func (c *cone) inBounds(r core.Ray, t float64) bool {
+	y := r.Origin().Y() + t*r.Direction().Y()
+	return c.min < y && y < c.max
+}
+
+func (c *cone) Intersect(r core.Ray) []float64 {
+	rox, roy, roz := r.Origin().X(), r.Origin().Y(), r.Origin().Z()
+	rdx, rdy, rdz := r.Direction().X(), r.Direction().Y(), r.Direction().Z()
+
+	a := rdx*rdx - rdy*rdy + rdz*rdz
+	b := 2*rox*rdx - 2*roy*rdy + 2*roz*rdz
+	cc := rox*rox - roy*roy + roz*roz
+
+	ts := []float64{}
+
+	if utils.AlmostEqual(a, 0.0) {
+		// Ray parallel to one of the halves hits the other at most once
+		if !utils.AlmostEqual(b, 0.0) {
+			t := -cc / (2 * b)
+			if c.inBounds(r, t) {
+				ts = append(ts, t)
+			}
+		}
+		return append(ts, c.intersectCaps(r)...)
+	}
+
+	disc := b*b - 4*a*cc
+	if disc < 0.0 {
+		return c.intersectCaps(r)
+	}
+
+	t0 := (-b - m.Sqrt(disc)) / (2 * a)
+	t1 := (-b + m.Sqrt(disc)) / (2 * a)
+	if t0 > t1 { // swap
+		t0, t1 = t1, t0
+	}
+
+	if c.inBounds(r, t0) {
+		ts = append(ts, t0)
+	}
+	if c.inBounds(r, t1) {
+		ts = append(ts, t1)
+	}
+
+	return append(ts, c.intersectCaps(r)...)
+}
+
+func (c *cone) Normal(p math.Point) math.Vector {
+	dist := m.Pow(p.X(), 2) + m.Pow(p.Z(), 2)
+
+	if dist < c.max*c.max && p.Y() >= (c.max-utils.Epsilon) {
+		return math.NewVector(0, 1, 0)
+	}
+
+	if dist < c.min*c.min && p.Y() <= (c.min+utils.Epsilon) {
+		return math.NewVector(0, -1, 0)
+	}
+
+	y := m.Sqrt(dist)
+	if p.Y() > 0 {
+		y = -y
+	}
+	return math.NewVector(p.X(), y, p.Z())
+}
+
+func (c *cone) Bounds() math.Bounds {
+	r := m.Max(m.Abs(c.min), m.Abs(c.max))
+	return math.NewBounds(math.NewPoint(-r, c.min, -r), math.NewPoint(r, c.max, r))
+}
+
+func (c *cone) String() string {
+	return "ConeMesh()"
+}
@@ -0,0 +1,92 @@
+package meshes_test
+
+import (
+	m "math"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/meshes"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+func TestConeHit(t *testing.T) {
+	cm := meshes.ConeMesh()
+
+	type Test struct {
+		ray      ray.Ray
+		expected []float64
+	}
+
+	tests := []Test{
+		{
+			ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1)),
+			[]float64{5, 5}},
+		{
+			ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(1, 1, 1).Normalize()),
+			[]float64{8.66025, 8.66025}},
+		{
+			ray.NewRay(math.NewPoint(1, 1, -5), math.NewVector(-0.5, -1, 1).Normalize()),
+			[]float64{4.55006, 49.44994}},
+	}
+
+	for _, test := range tests {
+		ts := cm.Intersect(test.ray)
+		if len(ts) != 2 || !utils.AlmostEqual(ts[0], test.expected[0]) || !utils.AlmostEqual(ts[1], test.expected[1]) {
+			t.Errorf("Cone hit failed for %v. Expected %v, got %v", test.ray, test.expected, ts)
+		}
+	}
+}
+
+func TestConeHitParallelToHalf(t *testing.T) {
+	cm := meshes.ConeMesh()
+	r := ray.NewRay(math.NewPoint(0, 0, -1), math.NewVector(0, 1, 1).Normalize())
+	ts := cm.Intersect(r)
+	if len(ts) != 1 || !utils.AlmostEqual(ts[0], 0.35355) {
+		t.Errorf("Ray parallel to cone half should hit once at 0.35355. Got %v", ts)
+	}
+}
+
+func TestClosedConeIntersect(t *testing.T) {
+	cm := meshes.ConeClosedMesh(-0.5, 0.5)
+
+	type Test struct {
+		r  ray.Ray
+		ts int
+	}
+
+	tests := []Test{
+		{ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 1, 0)), 0},
+		{ray.NewRay(math.NewPoint(0, 0, -0.25), math.NewVector(0, 1, 1).Normalize()), 2},
+		{ray.NewRay(math.NewPoint(0, 0, -0.25), math.NewVector(0, 1, 0)), 4},
+	}
+
+	for _, test := range tests {
+		ts := cm.Intersect(test.r)
+		if len(ts) != test.ts {
+			t.Errorf("Closed cone intersect failure with %v. Expected %v intersect. Got %v", test.r, test.ts, ts)
+		}
+	}
+}
+
+func TestConeNormal(t *testing.T) {
+	cm := meshes.ConeMesh()
+
+	type Test struct {
+		p math.Point
+		n math.Vector
+	}
+
+	tests := []Test{
+		{math.NewPoint(0, 0, 0), math.NewVector(0, 0, 0)},
+		{math.NewPoint(1, 1, 1), math.NewVector(1, -m.Sqrt2, 1)},
+		{math.NewPoint(-1, -1, 0), math.NewVector(-1, 1, 0)},
+	}
+
+	for _, test := range tests {
+		n := cm.Normal(test.p)
+		if !n.Equal(test.n) {
+			t.Errorf("Failed to compute normal on cone. Expected %v, got %v", test.n, n)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package meshes
 
 import (
 	m "math"
+	"math/rand"
 
 	"github.com/bricef/ray-tracer/pkg/component"
 	"github.com/bricef/ray-tracer/pkg/core"
@@ -41,6 +42,10 @@ func (c *cube) Intersect(r core.Ray) []float64 {
 	return []float64{tmin, tmax}
 }
 
+func (c *cube) Bounds() math.Bounds {
+	return math.NewBounds(math.NewPoint(-1, -1, -1), math.NewPoint(1, 1, 1))
+}
+
 func (c *cube) Normal(p math.Point) math.Vector {
 	maxc := m.Max(m.Abs(p.X()), m.Max(m.Abs(p.Y()), m.Abs(p.Z())))
 
@@ -52,3 +57,21 @@ func (c *cube) Normal(p math.Point) math.Vector {
 	return math.NewVector(0, 0, p.Z())
 
 }
+
+// Sample picks a face of the cube, all of the same area, then a point on it.
+func (c *cube) Sample() (math.Point, math.Vector) {
+	axis := rand.Intn(3)
+	side := 1.0
+	if rand.Intn(2) == 0 {
+		side = -1
+	}
+	coords := [3]float64{2*rand.Float64() - 1, 2*rand.Float64() - 1, 2*rand.Float64() - 1}
+	coords[axis] = side
+	normal := [3]float64{}
+	normal[axis] = side
+	return math.NewPoint(coords[0], coords[1], coords[2]), math.NewVector(normal[0], normal[1], normal[2])
+}
+
+func (c *cube) Area() float64 {
+	return 24
+}
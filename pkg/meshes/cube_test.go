@@ -3,6 +3,7 @@ package meshes_test
 import (
 	"testing"
 
+	"github.com/bricef/ray-tracer/pkg/core"
 	"github.com/bricef/ray-tracer/pkg/math"
 	"github.com/bricef/ray-tracer/pkg/meshes"
 	"github.com/bricef/ray-tracer/pkg/ray"
@@ -77,3 +78,49 @@ func TestCubeNormal(t *testing.T) {
 	helper(math.NewPoint(1, 1, 1), math.NewVector(1, 0, 0))
 	helper(math.NewPoint(-1, -1, -1), math.NewVector(-1, 0, 0))
 }
+
+func TestMeshBounds(t *testing.T) {
+	type Case struct {
+		mesh core.Mesh
+		min  math.Point
+		max  math.Point
+	}
+	cases := []Case{
+		{meshes.SphereMesh(), math.NewPoint(-1, -1, -1), math.NewPoint(1, 1, 1)},
+		{meshes.CubeMesh(), math.NewPoint(-1, -1, -1), math.NewPoint(1, 1, 1)},
+		{meshes.CylinderMeshLimited(-5, 3), math.NewPoint(-1, -5, -1), math.NewPoint(1, 3, 1)},
+		{meshes.ConeMeshLimited(-5, 3), math.NewPoint(-5, -5, -5), math.NewPoint(5, 3, 5)},
+		{
+			meshes.TriangleMesh(math.NewPoint(-3, 7, 2), math.NewPoint(6, 2, -4), math.NewPoint(2, -1, -1)),
+			math.NewPoint(-3, -1, -4), math.NewPoint(6, 7, 2),
+		},
+	}
+	for _, c := range cases {
+		b := c.mesh.Bounds()
+		if !b.Min.Equal(c.min) || !b.Max.Equal(c.max) {
+			t.Errorf("Wrong bounds for %v. Expected %v -> %v, got %v", c.mesh, c.min, c.max, b)
+		}
+	}
+
+	for _, unbounded := range []core.Mesh{meshes.PlaneMesh(), meshes.CylinderMesh(), meshes.ConeMesh()} {
+		if !unbounded.Bounds().IsInfinite() {
+			t.Errorf("Expected %v to have infinite bounds", unbounded)
+		}
+	}
+}
+
+func TestCubeSamplesLieOnFaces(t *testing.T) {
+	c := meshes.CubeMesh().(core.Surface)
+	for i := 0; i < 100; i++ {
+		p, n := c.Sample()
+		if !n.Equal(c.Normal(p)) {
+			t.Fatalf("Sample %v has normal %v, expected the face normal %v", p, n, c.Normal(p))
+		}
+		if d := p.Sub(math.NewPoint(0, 0, 0)).AsVector().Dot(n); d != 1 {
+			t.Fatalf("Sample %v is not on the face with normal %v", p, n)
+		}
+	}
+	if c.Area() != 24 {
+		t.Errorf("Expected cube area of 24, got %v", c.Area())
+	}
+}
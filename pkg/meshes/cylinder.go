@@ -116,3 +116,7 @@ func (c *cylinder) Normal(p math.Point) math.Vector {
 
 	return math.NewVector(p.X(), 0, p.Z()).Normalize()
 }
+
+func (c *cylinder) Bounds() math.Bounds {
+	return math.NewBounds(math.NewPoint(-1, c.min, -1), math.NewPoint(1, c.max, 1))
+}
@@ -34,6 +34,13 @@ func (plane *planeMesh) Intersect(r core.Ray) []float64 {
 	return []float64{t}
 }
 
+func (plane *planeMesh) Bounds() math.Bounds {
+	return math.NewBounds(
+		math.NewPoint(m.Inf(-1), 0, m.Inf(-1)),
+		math.NewPoint(m.Inf(+1), 0, m.Inf(+1)),
+	)
+}
+
 func (plane *planeMesh) String() string {
 	return "PlaneMesh()"
 }
@@ -2,6 +2,7 @@ package meshes
 
 import (
 	m "math"
+	"math/rand"
 
 	"github.com/bricef/ray-tracer/pkg/component"
 	"github.com/bricef/ray-tracer/pkg/core"
@@ -41,6 +42,23 @@ func (s *sphere) Intersect(r core.Ray) []float64 {
 
 }
 
+func (s *sphere) Bounds() math.Bounds {
+	return math.NewBounds(math.NewPoint(-1, -1, -1), math.NewPoint(1, 1, 1))
+}
+
+// Sample picks a point uniformly on the unit sphere.
+func (s *sphere) Sample() (math.Point, math.Vector) {
+	z := 1 - 2*rand.Float64()
+	r := m.Sqrt(1 - z*z)
+	phi := 2 * m.Pi * rand.Float64()
+	n := math.NewVector(r*m.Cos(phi), r*m.Sin(phi), z)
+	return math.NewPoint(n.X(), n.Y(), n.Z()), n
+}
+
+func (s *sphere) Area() float64 {
+	return 4 * m.Pi
+}
+
 func (s *sphere) String() string {
 	return "SphereMesh()"
 }
@@ -60,3 +60,16 @@ func TestSphereNormalsAreNormalised(t *testing.T) {
 		t.Errorf("Sphere normal %v. Expected magnitude 1.0, got %v", normal, normal.Magnitude())
 	}
 }
+
+func TestSphereSamplesLieOnSurface(t *testing.T) {
+	s := meshes.SphereMesh().(core.Surface)
+	for i := 0; i < 100; i++ {
+		p, n := s.Sample()
+		if !p.Sub(m.NewPoint(0, 0, 0)).AsVector().Equal(n) || math.Abs(n.Magnitude()-1) > 1e-9 {
+			t.Fatalf("Sample %v with normal %v is not on the unit sphere", p, n)
+		}
+	}
+	if s.Area() != 4*math.Pi {
+		t.Errorf("Expected unit sphere area of 4π, got %v", s.Area())
+	}
+}
@@ -0,0 +1,145 @@
+package meshes
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/component"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+type Triangle struct {
+	p1     math.Point
+	p2     math.Point
+	p3     math.Point
+	e1     math.Vector
+	e2     math.Vector
+	normal math.Vector
+}
+
+func TriangleMesh(p1, p2, p3 math.Point) *Triangle {
+	e1 := p2.Sub(p1).AsVector()
+	e2 := p3.Sub(p1).AsVector()
+	return &Triangle{
+		p1:     p1,
+		p2:     p2,
+		p3:     p3,
+		e1:     e1,
+		e2:     e2,
+		normal: e2.Cross(e1).Normalize(),
+	}
+}
+
+func (t *Triangle) Type() core.ComponentType {
+	return component.Mesh
+}
+
+func (t *Triangle) Points() (math.Point, math.Point, math.Point) {
+	return t.p1, t.p2, t.p3
+}
+
+func (t *Triangle) Edges() (math.Vector, math.Vector) {
+	return t.e1, t.e2
+}
+
+func (t *Triangle) Normal(p math.Point) math.Vector {
+	return t.normal
+}
+
+// Möller–Trumbore intersection
+func (t *Triangle) Intersect(r core.Ray) []float64 {
+	dirCrossE2 := r.Direction().Cross(t.e2)
+	det := t.e1.Dot(dirCrossE2)
+	if m.Abs(det) < utils.Epsilon { // Parallel ray to triangle
+		return []float64{}
+	}
+
+	f := 1.0 / det
+	p1ToOrigin := r.Origin().Sub(t.p1).AsVector()
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return []float64{}
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(t.e1)
+	v := f * r.Direction().Dot(originCrossE1)
+	if v < 0 || (u+v) > 1 {
+		return []float64{}
+	}
+
+	return []float64{f * t.e2.Dot(originCrossE1)}
+}
+
+func (t *Triangle) Bounds() math.Bounds {
+	return math.EmptyBounds().Add(t.p1).Add(t.p2).Add(t.p3)
+}
+
+// Sample picks a point uniformly on the triangle. The geometric normal is
+// returned, even for smooth triangles.
+func (t *Triangle) Sample() (math.Point, math.Vector) {
+	// Folding the square of (u, v) onto the triangle keeps the density uniform
+	u, v := rand.Float64(), rand.Float64()
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	return t.p1.Add(t.e1.Scale(u)).Add(t.e2.Scale(v)).AsPoint(), t.normal
+}
+
+func (t *Triangle) Area() float64 {
+	return t.e1.Cross(t.e2).Magnitude() / 2
+}
+
+// barycentric returns the weights of p2 and p3 for a point on the triangle.
+func (t *Triangle) barycentric(p math.Point) (float64, float64) {
+	d := p.Sub(t.p1).AsVector()
+	d00 := t.e1.Dot(t.e1)
+	d01 := t.e1.Dot(t.e2)
+	d11 := t.e2.Dot(t.e2)
+	d20 := d.Dot(t.e1)
+	d21 := d.Dot(t.e2)
+	denom := d00*d11 - d01*d01
+	u := (d11*d20 - d01*d21) / denom
+	v := (d00*d21 - d01*d20) / denom
+	return u, v
+}
+
+func (t *Triangle) String() string {
+	return "TriangleMesh()"
+}
+
+type SmoothTriangle struct {
+	Triangle
+	n1 math.Vector
+	n2 math.Vector
+	n3 math.Vector
+}
+
+func SmoothTriangleMesh(p1, p2, p3 math.Point, n1, n2, n3 math.Vector) *SmoothTriangle {
+	return &SmoothTriangle{
+		Triangle: *TriangleMesh(p1, p2, p3),
+		n1:       n1,
+		n2:       n2,
+		n3:       n3,
+	}
+}
+
+func (t *SmoothTriangle) Normals() (math.Vector, math.Vector, math.Vector) {
+	return t.n1, t.n2, t.n3
+}
+
+// Normal interpolates the vertex normals using the barycentric coordinates of
+// the point on the triangle.
+func (t *SmoothTriangle) Normal(p math.Point) math.Vector {
+	u, v := t.barycentric(p)
+	return t.n2.Scale(u).
+		Add(t.n3.Scale(v)).
+		Add(t.n1.Scale(1 - u - v)).
+		AsVector().
+		Normalize()
+}
+
+func (t *SmoothTriangle) String() string {
+	return "SmoothTriangleMesh()"
+}
@@ -0,0 +1,109 @@
+package meshes_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/meshes"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+func defaultTriangle() *meshes.Triangle {
+	return meshes.TriangleMesh(
+		math.NewPoint(0, 1, 0),
+		math.NewPoint(-1, 0, 0),
+		math.NewPoint(1, 0, 0),
+	)
+}
+
+func TestTriangleConstruction(t *testing.T) {
+	tri := defaultTriangle()
+	e1, e2 := tri.Edges()
+	n := tri.Normal(math.NewPoint(0, 0, 0))
+
+	if !e1.Equal(math.NewVector(-1, -1, 0)) || !e2.Equal(math.NewVector(1, -1, 0)) {
+		t.Errorf("Triangle edges computed incorrectly. Got %v, %v", e1, e2)
+	}
+	if !n.Equal(math.NewVector(0, 0, -1)) {
+		t.Errorf("Triangle normal computed incorrectly. Expected %v, got %v", math.NewVector(0, 0, -1), n)
+	}
+}
+
+func TestTriangleRayMiss(t *testing.T) {
+	tri := defaultTriangle()
+	rays := []ray.Ray{
+		ray.NewRay(math.NewPoint(0, -1, -2), math.NewVector(0, 1, 0)), // parallel
+		ray.NewRay(math.NewPoint(1, 1, -2), math.NewVector(0, 0, 1)),  // p1-p3 edge
+		ray.NewRay(math.NewPoint(-1, 1, -2), math.NewVector(0, 0, 1)), // p1-p2 edge
+		ray.NewRay(math.NewPoint(0, -1, -2), math.NewVector(0, 0, 1)), // p2-p3 edge
+	}
+	for _, r := range rays {
+		if ts := tri.Intersect(r); len(ts) != 0 {
+			t.Errorf("Ray %v should miss triangle. Got %v", r, ts)
+		}
+	}
+}
+
+func TestTriangleRayHit(t *testing.T) {
+	tri := defaultTriangle()
+	r := ray.NewRay(math.NewPoint(0, 0.5, -2), math.NewVector(0, 0, 1))
+	ts := tri.Intersect(r)
+	if len(ts) != 1 || !utils.AlmostEqual(ts[0], 2) {
+		t.Errorf("Expected one triangle intersection at t=2. Got %v", ts)
+	}
+}
+
+func TestTriangleNormalIsConstant(t *testing.T) {
+	tri := defaultTriangle()
+	expected := math.NewVector(0, 0, -1)
+	for _, p := range []math.Point{
+		math.NewPoint(0, 0.5, 0),
+		math.NewPoint(-0.5, 0.75, 0),
+		math.NewPoint(0.5, 0.25, 0),
+	} {
+		if n := tri.Normal(p); !n.Equal(expected) {
+			t.Errorf("Triangle normal at %v should be %v. Got %v", p, expected, n)
+		}
+	}
+}
+
+func TestSmoothTriangleInterpolatesNormal(t *testing.T) {
+	tri := meshes.SmoothTriangleMesh(
+		math.NewPoint(0, 1, 0),
+		math.NewPoint(-1, 0, 0),
+		math.NewPoint(1, 0, 0),
+		math.NewVector(0, 1, 0),
+		math.NewVector(-1, 0, 0),
+		math.NewVector(1, 0, 0),
+	)
+	r := ray.NewRay(math.NewPoint(-0.2, 0.3, -2), math.NewVector(0, 0, 1))
+	ts := tri.Intersect(r)
+	if len(ts) != 1 {
+		t.Fatalf("Expected ray to hit smooth triangle. Got %v", ts)
+	}
+
+	n := tri.Normal(r.Position(ts[0]))
+	expected := math.NewVector(-0.5547, 0.83205, 0)
+	if !n.Equal(expected) {
+		t.Errorf("Smooth triangle failed to interpolate normal. Expected %v, got %v", expected, n)
+	}
+}
+
+func TestTriangleSamplesLieInside(t *testing.T) {
+	tri := defaultTriangle()
+	for i := 0; i < 100; i++ {
+		p, n := tri.Sample()
+		if !n.Equal(tri.Normal(p)) {
+			t.Fatalf("Expected sample normal %v, got %v", tri.Normal(p), n)
+		}
+		// Rays through the sample along the normal hit the triangle
+		r := ray.NewRay(p.Add(n).AsPoint(), n.Invert())
+		if xs := tri.Intersect(r); len(xs) != 1 {
+			t.Fatalf("Sample %v is not inside the triangle", p)
+		}
+	}
+	if tri.Area() != 1 {
+		t.Errorf("Expected triangle area of 1, got %v", tri.Area())
+	}
+}
@@ -0,0 +1,196 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// Error is returned for malformed records in a Wavefront OBJ file.
+type Error struct {
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %v: %v", e.Line, e.Message)
+}
+
+// File holds the parsed contents of a Wavefront OBJ file. Vertices and Normals
+// are zero-indexed, whereas OBJ records count from one.
+type File struct {
+	Vertices     []math.Point
+	Normals      []math.Vector
+	DefaultGroup core.Entity
+	Groups       map[string]core.Entity
+	GroupNames   []string
+	Ignored      int
+}
+
+func LoadFile(filename string) (core.Entity, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	parsed, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", filename, err)
+	}
+	return parsed.Group(), nil
+}
+
+// Parse reads vertex (v), vertex normal (vn), face (f) and group (g) records.
+// Polygonal faces are triangulated as a fan around their first vertex. Any
+// other record is ignored.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{
+		DefaultGroup: entities.NewGroup(),
+		Groups:       map[string]core.Entity{},
+	}
+	current := f.DefaultGroup
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			v, err := parseTriple(fields[1:])
+			if err != nil {
+				return nil, &Error{line, err.Error()}
+			}
+			f.Vertices = append(f.Vertices, math.NewPoint(v[0], v[1], v[2]))
+		case "vn":
+			v, err := parseTriple(fields[1:])
+			if err != nil {
+				return nil, &Error{line, err.Error()}
+			}
+			f.Normals = append(f.Normals, math.NewVector(v[0], v[1], v[2]))
+		case "f":
+			triangles, err := f.face(fields[1:])
+			if err != nil {
+				return nil, &Error{line, err.Error()}
+			}
+			for _, t := range triangles {
+				current.AddChild(t)
+			}
+		case "g":
+			if len(fields) < 2 {
+				return nil, &Error{line, "group record without a name"}
+			}
+			name := strings.Join(fields[1:], " ")
+			g, ok := f.Groups[name]
+			if !ok {
+				g = entities.NewGroup().SetName(name)
+				f.Groups[name] = g
+				f.GroupNames = append(f.GroupNames, name)
+			}
+			current = g
+		default:
+			f.Ignored++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Group returns a single group holding every face in the file, with the
+// default and named groups as sub-groups.
+func (f *File) Group() core.Entity {
+	g := entities.NewGroup()
+	if f.DefaultGroup.HasChildren() {
+		g.AddChild(f.DefaultGroup)
+	}
+	for _, name := range f.GroupNames {
+		g.AddChild(f.Groups[name])
+	}
+	return g
+}
+
+func (f *File) face(refs []string) ([]core.Entity, error) {
+	if len(refs) < 3 {
+		return nil, fmt.Errorf("face needs at least 3 vertices. Got %v", len(refs))
+	}
+
+	vs := make([]math.Point, len(refs))
+	ns := make([]math.Vector, len(refs))
+	smooth := true
+	for i, ref := range refs {
+		// v, v/vt, v//vn or v/vt/vn
+		parts := strings.Split(ref, "/")
+		vi, err := index(parts[0], len(f.Vertices))
+		if err != nil {
+			return nil, fmt.Errorf("bad vertex reference %q: %v", ref, err)
+		}
+		vs[i] = f.Vertices[vi]
+
+		if len(parts) == 3 && parts[2] != "" {
+			ni, err := index(parts[2], len(f.Normals))
+			if err != nil {
+				return nil, fmt.Errorf("bad normal reference %q: %v", ref, err)
+			}
+			ns[i] = f.Normals[ni]
+		} else {
+			smooth = false
+		}
+	}
+
+	triangles := []core.Entity{}
+	for i := 1; i < len(vs)-1; i++ {
+		if smooth {
+			triangles = append(triangles, entities.NewSmoothTriangle(
+				vs[0], vs[i], vs[i+1],
+				ns[0], ns[i], ns[i+1],
+			))
+		} else {
+			triangles = append(triangles, entities.NewTriangle(vs[0], vs[i], vs[i+1]))
+		}
+	}
+	return triangles, nil
+}
+
+// index converts a one-based (or negative, relative) OBJ index into a
+// zero-based slice index.
+func index(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 {
+		i = count + i + 1
+	}
+	if i < 1 || i > count {
+		return 0, fmt.Errorf("index out of range (have %v)", count)
+	}
+	return i - 1, nil
+}
+
+func parseTriple(fields []string) ([3]float64, error) {
+	var v [3]float64
+	if len(fields) < 3 {
+		return v, fmt.Errorf("expected 3 coordinates. Got %v", len(fields))
+	}
+	for i := range v {
+		f, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return v, fmt.Errorf("bad coordinate %q", fields[i])
+		}
+		v[i] = f
+	}
+	return v, nil
+}
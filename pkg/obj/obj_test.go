@@ -0,0 +1,166 @@
+package obj_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/meshes"
+	"github.com/bricef/ray-tracer/pkg/obj"
+	"github.com/bricef/ray-tracer/pkg/ray"
+)
+
+func points(t *testing.T, e core.Entity) (math.Point, math.Point, math.Point) {
+	tri, ok := e.GetMesh().(*meshes.Triangle)
+	if !ok {
+		t.Fatalf("Expected a triangle mesh, got %v", e.GetMesh())
+	}
+	return tri.Points()
+}
+
+func checkTriangle(t *testing.T, e core.Entity, a, b, c math.Point) {
+	p1, p2, p3 := points(t, e)
+	if !p1.Equal(a) || !p2.Equal(b) || !p3.Equal(c) {
+		t.Errorf("Triangle has wrong vertices. Expected %v %v %v, got %v %v %v", a, b, c, p1, p2, p3)
+	}
+}
+
+func TestIgnoringUnrecognisedLines(t *testing.T) {
+	gibberish := `There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.`
+
+	f, err := obj.Parse(strings.NewReader(gibberish))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if f.Ignored != 5 {
+		t.Errorf("Expected parser to ignore 5 lines. Ignored %v", f.Ignored)
+	}
+}
+
+func TestVertexRecords(t *testing.T) {
+	f, err := obj.Parse(strings.NewReader(`v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expected := []math.Point{
+		math.NewPoint(-1, 1, 0),
+		math.NewPoint(-1, 0.5, 0),
+		math.NewPoint(1, 0, 0),
+		math.NewPoint(1, 1, 0),
+	}
+	for i, v := range expected {
+		if !f.Vertices[i].Equal(v) {
+			t.Errorf("Vertex %v parsed incorrectly. Expected %v, got %v", i+1, v, f.Vertices[i])
+		}
+	}
+}
+
+func TestTriangulatingPolygons(t *testing.T) {
+	f, err := obj.Parse(strings.NewReader(`v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	ts := f.DefaultGroup.Children()
+	if len(ts) != 3 {
+		t.Fatalf("Expected polygon to be split in 3 triangles. Got %v", len(ts))
+	}
+	vs := f.Vertices
+	checkTriangle(t, ts[0], vs[0], vs[1], vs[2])
+	checkTriangle(t, ts[1], vs[0], vs[2], vs[3])
+	checkTriangle(t, ts[2], vs[0], vs[3], vs[4])
+}
+
+func TestTrianglesInGroups(t *testing.T) {
+	file, err := os.Open("../../specs/code/files/triangles.obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	f, err := obj.Parse(file)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	vs := f.Vertices
+	checkTriangle(t, f.Groups["FirstGroup"].Children()[0], vs[0], vs[1], vs[2])
+	checkTriangle(t, f.Groups["SecondGroup"].Children()[0], vs[0], vs[2], vs[3])
+
+	g := f.Group()
+	if !core.Contains(g.Children(), f.Groups["FirstGroup"]) || !core.Contains(g.Children(), f.Groups["SecondGroup"]) {
+		t.Errorf("Converted group does not include named groups. Got %v", g.Children())
+	}
+}
+
+func TestFacesWithNormals(t *testing.T) {
+	f, err := obj.Parse(strings.NewReader(`v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+f 1//3 2//1 3//2
+f 1/0/3 2/102/1 3/14/2`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	for _, e := range f.DefaultGroup.Children() {
+		tri, ok := e.GetMesh().(*meshes.SmoothTriangle)
+		if !ok {
+			t.Fatalf("Expected smooth triangle for face with normals. Got %v", e.GetMesh())
+		}
+		n1, n2, n3 := tri.Normals()
+		if !n1.Equal(f.Normals[2]) || !n2.Equal(f.Normals[0]) || !n3.Equal(f.Normals[1]) {
+			t.Errorf("Smooth triangle has wrong normals. Got %v %v %v", n1, n2, n3)
+		}
+	}
+}
+
+func TestBadFaceReferenceIsAnError(t *testing.T) {
+	_, err := obj.Parse(strings.NewReader("v 0 1 0\nv -1 0 0\n\nf 1 2 3\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 4") {
+		t.Errorf("Expected an error on line 4 for a missing vertex. Got %v", err)
+	}
+}
+
+func TestRayHitsTrianglesInNamedGroups(t *testing.T) {
+	f, err := obj.Parse(strings.NewReader(`v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	g := f.Group().Translate(0, 0, 2)
+
+	r := ray.NewRay(math.NewPoint(0.5, 0.9, -5), math.NewVector(0, 0, 1))
+	xs := r.GetIntersections([]core.Entity{g})
+
+	if xs.Hit == nil || xs.Hit.Entity != f.Groups["SecondGroup"].Children()[0] || xs.Hit.T != 7 {
+		t.Errorf("Expected ray to hit the triangle in SecondGroup at t=7. Got %v", xs.Hit)
+	}
+}
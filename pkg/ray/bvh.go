@@ -0,0 +1,156 @@
+package ray
+
+import (
+	"sort"
+
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// Number of entities below which a BVH node is not split further
+const bvhLeafSize = 4
+
+// BVH is a bounding volume hierarchy over a list of entities. Entities with
+// infinite bounds (planes, unbounded cylinders...) cannot be partitioned and
+// are always candidates. Groups get a hierarchy of their own over their
+// children, so that rays skip whole subtrees of large models.
+type BVH struct {
+	unbounded []core.Entity
+	root      *bvhNode
+	// Hierarchies over the children of every group below the entities,
+	// shared by all levels
+	groups map[core.Entity]*BVH
+}
+
+type bvhNode struct {
+	bounds math.Bounds
+	items  []bvhItem
+	left   *bvhNode
+	right  *bvhNode
+}
+
+type bvhItem struct {
+	entity   core.Entity
+	bounds   math.Bounds
+	centroid math.Point
+}
+
+// NewBVH partitions the entities and the children of groups below them using
+// their bounds at the time of the call. It must be rebuilt when entities move.
+func NewBVH(es []core.Entity) *BVH {
+	bvh := newBVH(es)
+	bvh.groups = map[core.Entity]*BVH{}
+	for _, e := range es {
+		bvh.addGroups(e)
+	}
+	return bvh
+}
+
+// addGroups builds the hierarchies of e and the groups below it. The operands
+// of CSG entities are intersected as a whole and are left alone.
+func (b *BVH) addGroups(e core.Entity) {
+	if e.GetCSG() != nil || !e.HasChildren() {
+		return
+	}
+	b.groups[e] = newBVH(e.Children())
+	for _, c := range e.Children() {
+		b.addGroups(c)
+	}
+}
+
+// children returns the children of e that the ray, expressed in the space of
+// e, may hit.
+func (b *BVH) children(e core.Entity, object Ray) []core.Entity {
+	if b != nil {
+		if g, ok := b.groups[e]; ok {
+			return g.Candidates(object)
+		}
+	}
+	return e.Children()
+}
+
+func newBVH(es []core.Entity) *BVH {
+	bvh := &BVH{}
+	items := []bvhItem{}
+	for _, e := range es {
+		b := e.Bounds()
+		switch {
+		case b.IsInfinite():
+			bvh.unbounded = append(bvh.unbounded, e)
+		case !b.IsEmpty():
+			items = append(items, bvhItem{e, b, b.Center()})
+		}
+	}
+	if len(items) > 0 {
+		bvh.root = buildBVH(items)
+	}
+	return bvh
+}
+
+func buildBVH(items []bvhItem) *bvhNode {
+	n := &bvhNode{bounds: math.EmptyBounds()}
+	centroids := math.EmptyBounds()
+	for _, it := range items {
+		n.bounds = n.bounds.Merge(it.bounds)
+		centroids = centroids.Add(it.centroid)
+	}
+
+	if len(items) <= bvhLeafSize {
+		n.items = items
+		return n
+	}
+
+	// Split at the median along the axis with the largest spread
+	extent := centroids.Max.Sub(centroids.Min)
+	axis := func(p math.Point) float64 { return p.X() }
+	if extent.Y() > extent.X() && extent.Y() >= extent.Z() {
+		axis = func(p math.Point) float64 { return p.Y() }
+	} else if extent.Z() > extent.X() && extent.Z() > extent.Y() {
+		axis = func(p math.Point) float64 { return p.Z() }
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return axis(items[i].centroid) < axis(items[j].centroid)
+	})
+
+	mid := len(items) / 2
+	n.left = buildBVH(items[:mid])
+	n.right = buildBVH(items[mid:])
+	return n
+}
+
+// Candidates returns the entities whose bounds the ray crosses.
+func (b *BVH) Candidates(r Ray) []core.Entity {
+	es := append([]core.Entity{}, b.unbounded...)
+	if b.root == nil {
+		return es
+	}
+	stack := []*bvhNode{b.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !n.bounds.Intersects(r.origin, r.direction) {
+			continue
+		}
+		for _, it := range n.items {
+			if it.bounds.Intersects(r.origin, r.direction) {
+				es = append(es, it.entity)
+			}
+		}
+		if n.left != nil {
+			stack = append(stack, n.left, n.right)
+		}
+	}
+	return es
+}
+
+// GetIntersectionsBVH is GetIntersections restricted to the entities of the
+// hierarchy, and the children of groups, whose bounds the ray crosses.
+func (r Ray) GetIntersectionsBVH(b *BVH) *Intersections {
+	xs := []*Intersection{}
+	for _, e := range b.Candidates(r) {
+		xs = r.intersectTree(e, r, xs, b)
+	}
+	merged := NewIntersections().Merge(&Intersections{All: xs})
+	refractiveIndices(merged.All)
+	return merged
+}
@@ -0,0 +1,115 @@
+package ray_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/entity"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/meshes"
+	"github.com/bricef/ray-tracer/pkg/ray"
+)
+
+func bvhTestEntities() []core.Entity {
+	es := []core.Entity{entities.NewPlane().Translate(0, -2, 0)}
+	for x := -5; x <= 5; x++ {
+		for z := -5; z <= 5; z++ {
+			es = append(es, entities.NewSphere().
+				Translate(float64(x)*3, 0, float64(z)*3).
+				Scale(0.5, 0.5, 0.5))
+		}
+	}
+	return es
+}
+
+func TestBVHSkipsEntitiesOffTheRay(t *testing.T) {
+	es := bvhTestEntities()
+	bvh := ray.NewBVH(es)
+	r := ray.NewRay(math.NewPoint(0, 0, -20), math.NewVector(0, 0, 1))
+
+	candidates := bvh.Candidates(r)
+
+	// The plane and the column of spheres at x=0
+	if len(candidates) != 12 {
+		t.Errorf("Expected 12 candidate entities, got %v", len(candidates))
+	}
+	if !core.Contains(candidates, es[0]) {
+		t.Errorf("Unbounded entities should always be candidates")
+	}
+}
+
+func TestBVHIntersectionsMatchLinearSearch(t *testing.T) {
+	es := bvhTestEntities()
+	bvh := ray.NewBVH(es)
+	rays := []ray.Ray{
+		ray.NewRay(math.NewPoint(0, 0, -20), math.NewVector(0, 0, 1)),
+		ray.NewRay(math.NewPoint(-20, 0.2, -20), math.NewVector(1, 0, 1).Normalize()),
+		ray.NewRay(math.NewPoint(3, 10, 3), math.NewVector(0, -1, 0)),
+		ray.NewRay(math.NewPoint(1.5, 10, 1.5), math.NewVector(0, -1, 0)),
+		ray.NewRay(math.NewPoint(0, 10, 0), math.NewVector(0, 1, 0)),
+	}
+
+	for _, r := range rays {
+		expected := r.GetIntersections(es)
+		result := r.GetIntersectionsBVH(bvh)
+		if len(result.All) != len(expected.All) {
+			t.Errorf("BVH found %v intersections for %v, linear search found %v", len(result.All), r, len(expected.All))
+			continue
+		}
+		for i := range expected.All {
+			if result.All[i].T != expected.All[i].T {
+				t.Errorf("BVH intersection %v for %v at t=%v. Expected t=%v", i, r, result.All[i].T, expected.All[i].T)
+			}
+		}
+		if (result.Hit == nil) != (expected.Hit == nil) || (result.Hit != nil && result.Hit.Entity != expected.Hit.Entity) {
+			t.Errorf("BVH hit %v differs from linear hit %v", result.Hit, expected.Hit)
+		}
+	}
+}
+
+// countingMesh is a sphere counting how many rays are tested against it.
+type countingMesh struct {
+	core.Mesh
+	count *int
+}
+
+func (c countingMesh) Intersect(r core.Ray) []float64 {
+	*c.count++
+	return c.Mesh.Intersect(r)
+}
+
+func TestBVHSkipsChildrenOfLargeGroups(t *testing.T) {
+	// A model loaded as a single group of many small parts
+	count := 0
+	parts := []core.Entity{}
+	for x := 0; x < 20; x++ {
+		for y := 0; y < 20; y++ {
+			for z := 0; z < 5; z++ {
+				parts = append(parts, entity.NewEntity().
+					AddComponent(countingMesh{meshes.SphereMesh(), &count}).
+					AddComponent(material.NewMaterial()).
+					Translate(float64(x)*3, float64(y)*3, float64(z)*3).
+					Scale(0.5, 0.5, 0.5))
+			}
+		}
+	}
+	group := entities.NewGroup(parts...).Translate(-30, -30, 0)
+	bvh := ray.NewBVH([]core.Entity{group})
+	r := ray.NewRay(math.NewPoint(0, 0, -20), math.NewVector(0, 0, 1))
+
+	xs := r.GetIntersectionsBVH(bvh)
+	if tested := count; tested > 20 {
+		t.Errorf("Expected only the parts along the ray to be tested, %v of %v were", tested, len(parts))
+	}
+
+	count = 0
+	expected := r.GetIntersections([]core.Entity{group})
+	if count != len(parts) {
+		t.Fatalf("Expected linear search to test all %v parts, got %v", len(parts), count)
+	}
+	if len(xs.All) != len(expected.All) || len(xs.All) != 10 {
+		t.Errorf("Expected the 10 intersections of the linear search, got %v", len(xs.All))
+	}
+}
@@ -2,6 +2,7 @@ package ray
 
 import (
 	"fmt"
+	m "math"
 
 	"github.com/bricef/ray-tracer/pkg/core"
 	"github.com/bricef/ray-tracer/pkg/math"
@@ -40,32 +41,57 @@ func (r Ray) Hit(e core.Entity) *Intersection {
 	return r.Intersect(e).Hit
 }
 
+// Intersect computes the intersections of a world space ray with the mesh of
+// an entity, wherever the entity sits in its hierarchy.
 func (r Ray) Intersect(e core.Entity) *Intersections {
-	tray := r.Transform(e.Transform().Inverse())
+	return r.intersectMesh(e, r.toObjectSpace(e))
+}
 
-	if m := e.GetMesh(); m == nil {
-		return &Intersections{}
+// toObjectSpace expresses the ray in the object space of e, composing the
+// transforms of all its ancestors.
+func (r Ray) toObjectSpace(e core.Entity) Ray {
+	local := r
+	if p := e.Parent(); p != nil {
+		local = r.toObjectSpace(p)
 	}
+	return local.Transform(e.Transform().Inverse()).(Ray)
+}
 
-	// icoords := intersects(tray, e) // []float64
+// intersectMesh intersects the mesh of e with object, the ray expressed in the
+// object space of e. Intersections are reported in world space.
+func (r Ray) intersectMesh(e core.Entity, object Ray) *Intersections {
+	if e.GetMesh() == nil {
+		return &Intersections{}
+	}
 
-	icoords := e.GetMesh().Intersect(tray)
+	icoords := e.GetMesh().Intersect(object)
 
 	// Short circuit on miss
 	if len(icoords) == 0 {
 		return &Intersections{}
 	}
 
-	xs := make([]*Intersection, len(icoords))
+	xs := make([]*Intersection, 0, len(icoords))
 	var hit *Intersection
 	hit = nil
-	for i, t := range icoords {
+	for _, t := range icoords {
+		if m.IsNaN(t) { // degenerate transform or mesh
+			continue
+		}
 		p := r.Position(t)
 		n := e.Normal(p)
+		// The material may bend the normal used for shading, but points are
+		// offset along the true normal so they stay on the right side of the
+		// surface.
+		shading := n
+		if mat := e.GetMaterial(); mat != nil {
+			shading = mat.NormalOn(e, p, n)
+		}
 		eye := r.direction.Invert()
 		inside := false
 		if n.Dot(eye) < 0 { // inside entity check
 			n = n.Invert()
+			shading = shading.Invert()
 			inside = true
 		}
 		x := &Intersection{
@@ -75,104 +101,112 @@ func (r Ray) Intersect(e core.Entity) *Intersections {
 			OverPoint:  p.Add(n.Scale(utils.Epsilon)),
 			UnderPoint: p.Sub(n.Scale(utils.Epsilon)),
 			EyeVector:  eye,
-			Normal:     n,
+			Normal:     shading,
 			Inside:     inside,
 
-			ReflectVector: r.direction.Reflect(n),
+			ReflectVector: r.direction.Reflect(shading),
 			N1:            0.0,
 			N2:            0.0,
 		}
-		xs[i] = x
+		xs = append(xs, x)
 		if t >= 0 && ((hit == nil) || t < hit.T) {
 			hit = x
 		}
 	}
-
-	if hit != nil && hit.T < 0 {
-		panic(fmt.Errorf("Hit.T < 0: %v", hit))
-	}
 	return &Intersections{All: xs, Hit: hit}
 }
 
 func (r Ray) GetIntersections(es []core.Entity) *Intersections {
-	xs := NewIntersections()
-	for _, e := range es {
-		mat := e.GetMaterial()
-		mesh := e.GetMesh()
-		if mat != nil && mesh != nil { // Ignore entities without mesh or material
-			xs = xs.Merge(r.Intersect(e))
-		}
-		if e.HasChildren() {
-			for _, child := range e.Children() {
-				tr := r.Transform(e.Transform().Inverse()).
-					Transform(child.Transform().Inverse()).(Ray)
-				xs = xs.Merge(tr.Intersect(child))
-			}
-		}
-	}
+	xs := r.collect(es)
+	refractiveIndices(xs.All)
+	return xs
+}
 
-	for i, x := range xs.All {
-		if i == 0 { // first item. assume 1.0 refraction incident
-			x.N1 = 1.0
-			x.N2 = x.Entity.GetMaterial().RefractiveIndex()
-		} else if len(xs.All) > 1 && i == (len(xs.All)-1) { // last item
-			x.N1 = xs.All[i-i].Entity.GetMaterial().RefractiveIndex()
-			x.N2 = 1.0
+// refractiveIndices sets N1 and N2 on every intersection by tracking which
+// entities the ray is inside of. Intersections behind the origin are included
+// so that rays starting inside an object see the correct medium.
+func refractiveIndices(xs []*Intersection) {
+	containers := []core.Entity{}
+	for _, x := range xs {
+		x.N1 = innermostIndex(containers)
+		if core.Contains(containers, x.Entity) {
+			containers = core.Remove(containers, x.Entity)
 		} else {
-			if xs.All[i-1].Inside && x.Inside { // Both inside
-				x.N1 = x.Entity.GetMaterial().RefractiveIndex()
-				x.N2 = xs.All[i+1].Entity.GetMaterial().RefractiveIndex()
-			} else if xs.All[i-1].Inside && !x.Inside { // Coming out of previous entity
-				x.N1 = xs.All[i-1].Entity.GetMaterial().RefractiveIndex()
-				x.N2 = 1.0
-			} else if !xs.All[i-1].Inside && x.Inside { // ???
-				x.N1 = xs.All[i-1].Entity.GetMaterial().RefractiveIndex()
-				x.N2 = xs.All[i+1].Entity.GetMaterial().RefractiveIndex()
-			} else { // both outside
-				x.N1 = xs.All[i-1].Entity.GetMaterial().RefractiveIndex()
-				x.N2 = x.Entity.GetMaterial().RefractiveIndex()
-			}
+			containers = append(containers, x.Entity)
 		}
+		x.N2 = innermostIndex(containers)
 	}
+}
 
-	// objects := []core.Entity{}
-	// hit := xs.Hit
-	// for _, x := range xs.All {
-	// 	if x == hit {
-	// 		if len(objects) > 0 {
-	// 			e := objects[len(objects)-1]
-	// 			if e.GetMaterial() != nil {
-	// 				x.N1 = e.GetMaterial().RefractiveIndex()
-	// 			} else {
-	// 				x.N1 = 1.0
-	// 			}
-	// 		} else {
-	// 			x.N1 = 1.0
-	// 		}
-	// 	}
-
-	// 	if core.Contains(objects, x.Entity) {
-	// 		objects = core.Remove(objects, x.Entity)
-	// 	} else {
-	// 		objects = append(objects, x.Entity)
-	// 	}
-
-	// 	if x == hit {
-	// 		if len(objects) == 0 {
-	// 			x.N2 = 1.0
-	// 		} else {
-	// 			o := objects[len(objects)-1]
-	// 			if o.GetMaterial() != nil {
-	// 				x.N2 = o.GetMaterial().RefractiveIndex()
-	// 			} else {
-	// 				x.N2 = 1.0
-	// 			}
-	// 		}
-	// 		break
-	// 	}
-	// }
+// innermostIndex is the refractive index of the most recently entered
+// container, or of the vacuum when the ray is outside everything.
+func innermostIndex(containers []core.Entity) float64 {
+	if len(containers) == 0 {
+		return 1.0
+	}
+	if mat := containers[len(containers)-1].GetMaterial(); mat != nil {
+		return mat.RefractiveIndex()
+	}
+	return 1.0
+}
+
+// collect gathers the sorted intersections of the ray with the entities and
+// all their descendants.
+func (r Ray) collect(es []core.Entity) *Intersections {
+	xs := []*Intersection{}
+	for _, e := range es {
+		xs = r.intersectTree(e, r, xs, nil)
+	}
+	return NewIntersections().Merge(&Intersections{All: xs})
+}
+
+// intersectTree appends the intersections of e and its descendants to xs.
+// local is the ray expressed in the space of the parent of e. Children of
+// groups are culled with the hierarchy b, when there is one.
+func (r Ray) intersectTree(e core.Entity, local Ray, xs []*Intersection, b *BVH) []*Intersection {
+	object := local.Transform(e.Transform().Inverse()).(Ray)
+	if e.GetCSG() != nil {
+		return append(xs, r.intersectCSG(e, object, b)...)
+	}
+	if e.GetMaterial() != nil && e.GetMesh() != nil { // Ignore entities without mesh or material
+		xs = append(xs, r.intersectMesh(e, object).All...)
+	}
+	for _, child := range b.children(e, object) {
+		xs = r.intersectTree(child, object, xs, b)
+	}
 	return xs
+}
+
+// intersectCSG intersects both operands of a CSG entity and keeps only the
+// intersections on the surface of the combined shape.
+func (r Ray) intersectCSG(e core.Entity, object Ray, b *BVH) []*Intersection {
+	children := e.Children()
+	if len(children) < 2 {
+		return []*Intersection{}
+	}
+	left := children[0]
+	op := e.GetCSG()
+
+	xs := []*Intersection{}
+	for _, child := range children[:2] {
+		xs = r.intersectTree(child, object, xs, b)
+	}
+
+	filtered := []*Intersection{}
+	inLeft, inRight := false, false
+	for _, x := range NewIntersections().Merge(&Intersections{All: xs}).All {
+		leftHit := core.IsDescendant(x.Entity, left)
+		if op.Allowed(leftHit, inLeft, inRight) {
+			filtered = append(filtered, x)
+		}
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
 
+	return filtered
 }
 
 func (r Ray) Transform(t math.Transform) core.Ray {
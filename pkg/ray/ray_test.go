@@ -207,6 +207,28 @@ func TestOverPointAboveSurface(t *testing.T) {
 
 }
 
+func TestNormalMapBendsShadingNormal(t *testing.T) {
+	tilted := math.NewVector(1, 1, 0).Normalize()
+	e := entities.NewPlane()
+	e.GetMaterial().SetNormalMap(func(p math.Point, n math.Vector) math.Vector {
+		return tilted
+	})
+	r := ray.NewRay(
+		math.NewPoint(0, 1, 0),
+		math.NewVector(0, -1, 0),
+	)
+	xs := r.Intersect(e)
+	if !xs.Hit.Normal.Equal(tilted) {
+		t.Errorf("Expected normal from the normal map %v. Got %v", tilted, xs.Hit.Normal)
+	}
+	if expected := math.NewVector(1, 0, 0); !xs.Hit.ReflectVector.Equal(expected) {
+		t.Errorf("Expected reflection off the bent normal %v. Got %v", expected, xs.Hit.ReflectVector)
+	}
+	if xs.Hit.OverPoint.X() != 0 || xs.Hit.OverPoint.Y() <= 0 {
+		t.Errorf("Expected over point offset along the true normal. Got %v", xs.Hit.OverPoint)
+	}
+}
+
 func TestIntesectionsHaveReflectVector(t *testing.T) {
 	shape := entities.NewPlane()
 
@@ -237,7 +259,7 @@ func TestReflectionOnNonReflectiveSurface(t *testing.T) {
 
 	xs := r.Intersect(e)
 
-	result := s.ReflectedContribution(xs.Hit, 10)
+	result := scene.Whitted{}.ReflectedContribution(s, xs.Hit, 10)
 	expected := color.Black
 
 	if !result.Equal(expected) {
@@ -261,7 +283,7 @@ func TestReflectionOnReflectiveSurfaceReturnsReflectedColor(t *testing.T) {
 
 	xs := r.Intersect(e)
 
-	result := s.ReflectedContribution(xs.Hit, 10)
+	result := scene.Whitted{}.ReflectedContribution(s, xs.Hit, 10)
 	expected := color.New(0.19033, 0.23792, 0.14274)
 	if !result.Equal(expected) {
 		t.Errorf("Failed to compute reflected color. Expected %v, got %v", expected, result)
@@ -326,3 +348,38 @@ func TestUnderPointBelowSurface(t *testing.T) {
 	}
 
 }
+
+func TestRefractionIndicesOfGlassInWater(t *testing.T) {
+	water := entities.NewCube().Scale(5, 5, 5)
+	water.GetMaterial().SetTransparency(1.0).SetRefractiveIndex(1.333)
+	glass := entities.NewGlassSphere()
+
+	// Starting underwater, the water's exit is behind the eye
+	r := ray.NewRay(
+		math.NewPoint(0, 0, -3),
+		math.NewVector(0, 0, 1),
+	)
+	xs := r.GetIntersections([]core.Entity{water, glass})
+
+	type helper struct {
+		N1 float64
+		N2 float64
+	}
+	tests := []helper{
+		{1.0, 1.333},
+		{1.333, 1.5},
+		{1.5, 1.333},
+		{1.333, 1.0},
+	}
+	if len(xs.All) != len(tests) {
+		t.Fatalf("Expected %v intersections, got %v", len(tests), len(xs.All))
+	}
+	for i, x := range xs.All {
+		if !(x.N1 == tests[i].N1 && x.N2 == tests[i].N2) {
+			t.Errorf("Intersection %v does not have correct indices of refraction. Expected %v->%v, got %v->%v", i, tests[i].N1, tests[i].N2, x.N1, x.N2)
+		}
+	}
+	if xs.Hit != xs.All[1] {
+		t.Errorf("Expected the hit to be entering the glass. Got %v", xs.Hit)
+	}
+}
@@ -0,0 +1,128 @@
+package scene
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+// Emitters are the entities of the scene whose material gives off light and
+// whose mesh can be sampled, so that they light other surfaces. Other
+// emissive entities only glow where rays hit them. Like the bounding volume
+// hierarchy, they are collected by Build, and found anew on every call until
+// the scene is built.
+func (s *Scene) Emitters() []core.Entity {
+	if s.bvh != nil {
+		return s.emitters
+	}
+	return emitters(s.Entities, nil)
+}
+
+// emitters appends the emitters among the entities and their descendants to
+// found. The operands of CSG entities are not sampled, as parts of their
+// surfaces are cut away.
+func emitters(es []core.Entity, found []core.Entity) []core.Entity {
+	for _, e := range es {
+		if e.GetCSG() != nil {
+			continue
+		}
+		if _, ok := e.GetMesh().(core.Surface); ok {
+			if mat := e.GetMaterial(); mat != nil && !mat.Emission().Equal(color.Black) {
+				found = append(found, e)
+			}
+		}
+		found = emitters(e.Children(), found)
+	}
+	return found
+}
+
+// sampled reports whether the light given off by the entity is already
+// accounted for by sampling its surface.
+func (s *Scene) sampled(e core.Entity) bool {
+	return core.Contains(s.Emitters(), e)
+}
+
+// emission is the light given off by the hit surface towards the eye.
+func emission(hit *ray.Intersection) color.Color {
+	mat := hit.Entity.GetMaterial()
+	if mat == nil {
+		return color.Black
+	}
+	return mat.Emission()
+}
+
+// sampleEmitter picks a random point on the emitter and returns the
+// direction and distance to it from p, with the intensity of the light it
+// sends to p. The intensity follows the convention of point lights: light
+// given off evenly over a whole hemisphere around p lights a white diffuse
+// surface as much as a point light of the same intensity shining straight
+// on it.
+func sampleEmitter(e core.Entity, p math.Point) (math.Vector, float64, color.Color, bool) {
+	surface := e.GetMesh().(core.Surface)
+	point, normal := surface.Sample()
+
+	// The transform stretches the surface around the point by the area of
+	// the parallelogram of its two transformed tangents.
+	t := normal.Cross(math.NewVector(1, 0, 0))
+	if t.Magnitude() < 0.1 {
+		t = normal.Cross(math.NewVector(0, 1, 0))
+	}
+	t = t.Normalize()
+	b := normal.Cross(t)
+	world := toWorld(e, point)
+	dt := toWorld(e, point.Add(t).AsPoint()).Sub(world).AsVector()
+	db := toWorld(e, point.Add(b).AsPoint()).Sub(world).AsVector()
+	stretch := dt.Cross(db)
+	area := surface.Area() * stretch.Magnitude()
+
+	path := world.Sub(p).AsVector()
+	distance := path.Magnitude()
+	if distance < utils.Epsilon {
+		return path, 0, color.Black, false
+	}
+	direction := path.Scale(1 / distance).AsVector()
+	// Both faces of a surface glow, as when rays hit them
+	cos := m.Abs(direction.Dot(stretch.Normalize()))
+	if cos == 0 {
+		return direction, distance, color.Black, false
+	}
+	intensity := e.GetMaterial().Emission().Scale(cos * area / (m.Pi * distance * distance))
+	return direction, distance, intensity, true
+}
+
+// toWorld takes a point in the object space of e to world space, composing
+// the transforms of all its ancestors.
+func toWorld(e core.Entity, p math.Point) math.Point {
+	for ; e != nil; e = e.Parent() {
+		p = e.Transform().Apply(p).AsPoint()
+	}
+	return p
+}
+
+// emitterLight is the light from the emitter reflected by the hit surface,
+// averaged over the given number of points on the emitter. Emitters do not
+// light themselves.
+func emitterLight(s *Scene, e core.Entity, mat core.Material, surface color.Color, hit *ray.Intersection, samples int) color.Color {
+	if e == hit.Entity {
+		return color.Black
+	}
+	c := color.Black
+	for i := 0; i < samples; i++ {
+		direction, distance, intensity, ok := sampleEmitter(e, hit.OverPoint)
+		if !ok || direction.Dot(hit.Normal) <= 0 {
+			continue
+		}
+		// Stop short of the emitter, which would otherwise shadow itself
+		filter := s.Transmittance(hit.OverPoint, direction, distance-utils.Epsilon)
+		if filter.Equal(color.Black) {
+			continue
+		}
+		c = c.Add(lighting.Direct(mat, surface, intensity.Mult(filter), direction, hit.EyeVector, hit.Normal))
+	}
+	return c.Scale(1 / float64(samples))
+}
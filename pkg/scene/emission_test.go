@@ -0,0 +1,111 @@
+package scene_test
+
+import (
+	m "math"
+	"math/rand"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+// glowingScene has a unit sphere glowing with the given radiance, four units
+// above a white diffuse floor and lit by nothing else.
+func glowingScene(radiance float64) (*scene.Scene, core.Entity) {
+	s := scene.NewScene()
+	s.Add(entities.NewPlane().AddComponent(
+		material.NewMaterial().SetSpecular(0),
+	))
+	glow := entities.NewSphere().Translate(0, 4, 0).AddComponent(
+		material.NewMaterial().
+			SetDiffuse(0).
+			SetSpecular(0).
+			SetEmission(color.White).
+			SetEmissionStrength(radiance),
+	)
+	s.Add(glow)
+	s.Build()
+	return s, glow
+}
+
+func TestEmissiveEntitiesAreSampled(t *testing.T) {
+	s, glow := glowingScene(1)
+	s.Add(entities.NewPlane().Translate(0, 10, 0).AddComponent(
+		material.NewMaterial().SetEmission(color.White),
+	))
+	if emitters := s.Emitters(); len(emitters) != 1 || emitters[0] != glow {
+		t.Errorf("Expected only the glowing sphere to be sampled, got %v", emitters)
+	}
+}
+
+func TestEmittersFoundAfterChanges(t *testing.T) {
+	s := scene.NewScene()
+	lamp := material.NewMaterial()
+	bulb := entities.NewSphere().AddComponent(lamp)
+	group := entities.NewGroup()
+	s.Add(bulb)
+	s.Add(group)
+
+	// Made emissive, and attached, after being added to the scene
+	lamp.SetEmission(color.White)
+	panel := entities.NewCube().AddComponent(material.NewMaterial().SetEmission(color.White))
+	group.AddChild(panel)
+
+	for _, built := range []bool{false, true} {
+		if built {
+			s.Build()
+		}
+		emitters := s.Emitters()
+		if len(emitters) != 2 || !core.Contains(emitters, bulb) || !core.Contains(emitters, panel) {
+			t.Errorf("Expected the bulb and the panel to be sampled (built: %v), got %v", built, emitters)
+		}
+	}
+}
+
+func TestEmissiveSurfaceVisible(t *testing.T) {
+	s, _ := glowingScene(2)
+	r := ray.NewRay(math.NewPoint(0, 4, -5), math.NewVector(0, 0, 1))
+	expected := color.White.Scale(2)
+	if c := (scene.Whitted{}).Radiance(s, r); !c.Equal(expected) {
+		t.Errorf("Expected glowing sphere to show as %v, got %v", expected, c)
+	}
+	if c := (scene.PathTracer{}).Radiance(s, r); !c.Equal(expected) {
+		t.Errorf("Expected path traced glowing sphere to show as %v, got %v", expected, c)
+	}
+}
+
+func TestEmissiveSurfaceLightsScene(t *testing.T) {
+	// Straight under a sphere of radius 1 at distance 4, glowing with radiance
+	// L, a diffuse surface of albedo 0.9 reflects 0.9 * L / 16.
+	s, _ := glowingScene(16)
+	w := scene.Whitted{EmitterSamples: 4096}
+	r := ray.NewRay(math.NewPoint(0, 1, -1), math.NewVector(0, -1, 1).Normalize())
+	expected := 0.9
+
+	// A single sample of the sphere has a standard deviation of about 1.6, so
+	// estimates are held to four standard errors. The source is seeded to
+	// keep the test reproducible.
+	rand.Seed(1)
+	tolerance := func(samples int) float64 { return 4 * 1.6 / m.Sqrt(float64(samples)) }
+
+	if c := w.Radiance(s, r); m.Abs(c.R-expected) > tolerance(w.EmitterSamples) {
+		t.Errorf("Expected floor under glowing sphere to be lit to %v, got %v", expected, c)
+	}
+
+	// Light bouncing off the floor escapes, as the sphere does not reflect
+	// it, so path tracing only sees direct light. Hitting the sphere after a
+	// bounce must not count its light twice.
+	total := 0.0
+	n := 16000
+	for i := 0; i < n; i++ {
+		total += scene.PathTracer{}.Radiance(s, r).R
+	}
+	if average := total / float64(n); m.Abs(average-expected) > tolerance(n) {
+		t.Errorf("Expected path traced floor to be lit to %v, got %v", expected, average)
+	}
+}
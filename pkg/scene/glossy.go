@@ -0,0 +1,37 @@
+package scene
+
+import (
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// perturb tilts the direction at random, further the rougher the surface. The
+// result stays on the side of the surface the direction points to, given by
+// the sign of its dot product with the normal.
+func perturb(direction, normal math.Vector, roughness float64) math.Vector {
+	if roughness == 0 {
+		return direction
+	}
+	side := direction.Dot(normal)
+	p := direction.Add(inUnitSphere().Scale(roughness)).AsVector()
+	if p.Magnitude() < 1e-9 {
+		return direction
+	}
+	p = p.Normalize()
+	if p.Dot(normal)*side < 0 {
+		// Mirror it back across the surface
+		p = p.Sub(normal.Scale(2 * p.Dot(normal))).AsVector()
+	}
+	return p
+}
+
+// inUnitSphere picks a random point in the unit ball, uniformly.
+func inUnitSphere() math.Vector {
+	for {
+		v := math.NewVector(2*rand.Float64()-1, 2*rand.Float64()-1, 2*rand.Float64()-1)
+		if v.Dot(v) < 1 {
+			return v
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+// besideGlow adds a glowing sphere just out of the way of a ray leaving the
+// origin towards +z.
+func besideGlow(s *scene.Scene) {
+	s.Add(entities.NewSphere().Translate(3, 1, 5).Scale(2, 2, 2).AddComponent(
+		material.NewMaterial().SetEmission(color.White),
+	))
+}
+
+// unlit is a material that only reflects or refracts light.
+func unlit() core.Material {
+	return material.NewMaterial().SetAmbient(0).SetDiffuse(0).SetSpecular(0)
+}
+
+func TestRoughReflectionBlurs(t *testing.T) {
+	mirror := unlit().SetReflective(1)
+	s := scene.NewScene()
+	s.Add(entities.NewPlane().AddComponent(mirror))
+	besideGlow(s)
+	w := scene.Whitted{GlossySamples: 64}
+	s.Build()
+	r := ray.NewRay(math.NewPoint(0, 1, -5), math.NewVector(0, -1, 5).Normalize())
+
+	if c := w.Radiance(s, r); !c.Equal(color.Black) {
+		t.Errorf("Expected a mirror to miss the glowing sphere. Got %v", c)
+	}
+	mirror.SetReflectionRoughness(0.8)
+	if c := w.Radiance(s, r); c.Equal(color.Black) {
+		t.Errorf("Expected a rough mirror to pick up some of the glowing sphere")
+	}
+}
+
+func TestFrostedRefractionBlurs(t *testing.T) {
+	glass := unlit().SetTransparency(1).SetRefractiveIndex(1)
+	s := scene.NewScene()
+	s.Add(entities.NewCube().Scale(3, 3, 0.1).AddComponent(glass))
+	besideGlow(s)
+	w := scene.Whitted{GlossySamples: 64}
+	s.Build()
+	r := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+
+	if c := w.Radiance(s, r); !c.Equal(color.Black) {
+		t.Errorf("Expected clear glass to show the dark background. Got %v", c)
+	}
+	glass.SetRefractionRoughness(0.8)
+	if c := w.Radiance(s, r); c.Equal(color.Black) {
+		t.Errorf("Expected frosted glass to let some of the glowing sphere through")
+	}
+}
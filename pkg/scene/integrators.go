@@ -0,0 +1,120 @@
+package scene
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/ray"
+)
+
+// Integrator computes the light arriving along a ray through a scene. Besides
+// the shading models, debug integrators show what the renderer sees of the
+// scene geometry.
+type Integrator interface {
+	Radiance(s *Scene, r ray.Ray) color.Color
+}
+
+// Normals shows the shading normal of surfaces, mapping each axis from
+// [-1, 1] to a channel in [0, 1].
+type Normals struct{}
+
+func (Normals) Radiance(s *Scene, r ray.Ray) color.Color {
+	hit := s.Intersections(r).Hit
+	if hit == nil {
+		return color.Black
+	}
+	n := hit.Normal
+	return color.New(n.X()+1, n.Y()+1, n.Z()+1).Scale(0.5)
+}
+
+// Depth shows the distance to surfaces, from white at the camera to black at
+// the far distance.
+type Depth struct {
+	Far float64
+}
+
+func (d Depth) Radiance(s *Scene, r ray.Ray) color.Color {
+	hit := s.Intersections(r).Hit
+	if hit == nil || d.Far <= 0 {
+		return color.Black
+	}
+	distance := hit.T * r.Direction().Magnitude()
+	return color.White.Scale(m.Max(0, 1-distance/d.Far))
+}
+
+// Albedo shows the color of surfaces, without any lighting.
+type Albedo struct{}
+
+func (Albedo) Radiance(s *Scene, r ray.Ray) color.Color {
+	hit := s.Intersections(r).Hit
+	if hit == nil {
+		return s.BackgroundColor
+	}
+	mat := hit.Entity.GetMaterial()
+	if mat == nil {
+		return color.Black
+	}
+	return mat.ColorOn(hit.Entity, hit.Point)
+}
+
+// AmbientOcclusion shows how much of the sky above surfaces is blocked by
+// nearby geometry, from white for open surfaces to black in closed corners.
+type AmbientOcclusion struct {
+	// Rays fired from each surface point. Zero uses 16.
+	Samples int
+	// Distance within which geometry occludes. Zero looks as far as it goes.
+	Distance float64
+}
+
+func (a AmbientOcclusion) Radiance(s *Scene, r ray.Ray) color.Color {
+	hit := s.Intersections(r).Hit
+	if hit == nil {
+		return color.White
+	}
+	samples := a.Samples
+	if samples <= 0 {
+		samples = 16
+	}
+	distance := a.Distance
+	if distance <= 0 {
+		distance = m.Inf(1)
+	}
+	open := 0
+	for i := 0; i < samples; i++ {
+		xs := s.Intersections(ray.NewRay(hit.OverPoint, cosineSample(hit.Normal)))
+		if xs.Hit == nil || xs.Hit.T > distance {
+			open++
+		}
+	}
+	return color.White.Scale(float64(open) / float64(samples))
+}
+
+// Heatmap shows how many surfaces rays cross, from black through blue, green
+// and red to white at Max.
+type Heatmap struct {
+	// Count shown as white. Zero uses 8.
+	Max int
+}
+
+// heat is the color ramp of the heatmap, evenly spaced over [0, 1].
+var heat = []color.Color{color.Black, color.Blue, color.Green, color.Red, color.White}
+
+func (h Heatmap) Radiance(s *Scene, r ray.Ray) color.Color {
+	max := h.Max
+	if max <= 0 {
+		max = 8
+	}
+	count := 0
+	for _, x := range s.Intersections(r).All {
+		if x.T >= 0 {
+			count++
+		}
+	}
+	v := m.Min(float64(count)/float64(max), 1) * float64(len(heat)-1)
+	i := int(m.Floor(v))
+	if i >= len(heat)-1 {
+		return heat[len(heat)-1]
+	}
+	f := v - float64(i)
+	return heat[i].Scale(1 - f).Add(heat[i+1].Scale(f))
+}
@@ -0,0 +1,86 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+var towardsOrigin = ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+
+// shadowed is an integrator built outside the scene package, showing lit
+// surfaces white and shadowed ones black.
+type shadowed struct{}
+
+func (shadowed) Radiance(s *scene.Scene, r ray.Ray) color.Color {
+	hit := s.Intersections(r).Hit
+	if hit == nil {
+		return color.Black
+	}
+	return s.Shadow(hit.OverPoint, s.Lights()[0])
+}
+
+func TestIntegrators(t *testing.T) {
+	s := scene.DefaultScene()
+	tests := []struct {
+		integrator scene.Integrator
+		expected   color.Color
+	}{
+		{scene.Whitted{}, color.New(0.38066, 0.47583, 0.2855)},
+		{scene.Whitted{Depth: 1}, color.New(0.38066, 0.47583, 0.2855)},
+		{scene.Normals{}, color.New(0.5, 0.5, 0)},
+		{scene.Depth{Far: 8}, color.New(0.5, 0.5, 0.5)},
+		{scene.Albedo{}, color.New(0.8, 1.0, 0.6)},
+		// The sphere in front and the one inside it, twice each
+		{scene.Heatmap{Max: 8}, color.New(0, 1, 0)},
+		{shadowed{}, color.White},
+	}
+	for _, test := range tests {
+		if c := test.integrator.Radiance(s, towardsOrigin); !c.Equal(test.expected) {
+			t.Errorf("Integrator %T. Expected %v, got %v", test.integrator, test.expected, c)
+		}
+	}
+}
+
+func TestIntegratorsOnMiss(t *testing.T) {
+	s := scene.DefaultScene()
+	s.BackgroundColor = color.Blue
+	away := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, -1))
+	tests := []struct {
+		integrator scene.Integrator
+		expected   color.Color
+	}{
+		{scene.Normals{}, color.Black},
+		{scene.Depth{Far: 8}, color.Black},
+		{scene.Albedo{}, color.Blue},
+		{scene.AmbientOcclusion{}, color.White},
+		{scene.Heatmap{}, color.Black},
+	}
+	for _, test := range tests {
+		if c := test.integrator.Radiance(s, away); !c.Equal(test.expected) {
+			t.Errorf("Integrator %T on a miss. Expected %v, got %v", test.integrator, test.expected, c)
+		}
+	}
+}
+
+func TestAmbientOcclusion(t *testing.T) {
+	s := scene.NewScene()
+	s.Add(entities.NewPlane())
+	// A roof close over part of the floor
+	s.Add(entities.NewPlane().Translate(0, 1, 0).AddComponent(material.NewMaterial()))
+	down := math.NewVector(0, -1, 0)
+
+	open := scene.AmbientOcclusion{Samples: 8}.Radiance(s, ray.NewRay(math.NewPoint(0, 0.5, 0), down))
+	if !open.Equal(color.Black) {
+		t.Errorf("Expected the floor under an infinite roof to be fully occluded. Got %v", open)
+	}
+	near := scene.AmbientOcclusion{Samples: 8, Distance: 0.5}.Radiance(s, ray.NewRay(math.NewPoint(0, 0.5, 0), down))
+	if !near.Equal(color.White) {
+		t.Errorf("Expected a roof further than the occlusion distance not to occlude. Got %v", near)
+	}
+}
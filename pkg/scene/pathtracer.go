@@ -0,0 +1,229 @@
+package scene
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+)
+
+// Number of bounces before paths may be terminated by Russian roulette
+const rouletteDepth = 3
+
+// PathTracer follows random paths of light for global illumination. Each ray
+// is one noisy sample, so pixels need many of them, e.g. with jittered
+// anti-aliasing.
+//
+// Lights are sampled directly at every bounce, and diffuse surfaces pass on
+// the light they receive from other surfaces and the background, so indirect
+// light and colour bleeding come out without an ambient term. Paths end by
+// Russian roulette rather than at a fixed depth, which keeps the estimate
+// unbiased.
+type PathTracer struct{}
+
+func (PathTracer) Radiance(s *Scene, r ray.Ray) color.Color {
+	radiance := color.Black
+	throughput := color.White
+	// Whether the last bounce was a reflection or refraction through the
+	// reflective or transparent parts of the material, which light sampling
+	// does not account for
+	specular := true
+	for bounce := 0; ; bounce++ {
+		hit := s.Intersections(r).Hit
+		if hit == nil {
+			return radiance.Add(throughput.Mult(s.BackgroundColor))
+		}
+		mat := hit.Entity.GetMaterial()
+		if mat == nil {
+			return radiance
+		}
+		// Emitters reached by other bounces were counted by light sampling
+		if specular || !s.sampled(hit.Entity) {
+			radiance = radiance.Add(throughput.Mult(mat.Emission()))
+		}
+		surface := mat.ColorOn(hit.Entity, hit.Point)
+
+		radiance = radiance.Add(throughput.Mult(directLight(s, mat, surface, hit)))
+
+		next, weight, delta, ok := scatter(mat, surface, hit)
+		if !ok {
+			return radiance
+		}
+		throughput = throughput.Mult(weight)
+		specular = delta
+		r = next
+
+		if bounce >= rouletteDepth {
+			survival := m.Min(m.Max(throughput.R, m.Max(throughput.G, throughput.B)), 0.95)
+			if rand.Float64() >= survival {
+				return radiance
+			}
+			throughput = throughput.Scale(1 / survival)
+		}
+	}
+}
+
+// directLight is the light reflected by the hit surface straight from every
+// light and emitter of the scene. Area lights and emitters are sampled at one
+// random point each time.
+func directLight(s *Scene, mat core.Material, surface color.Color, hit *ray.Intersection) color.Color {
+	c := color.Black
+	for _, le := range s.lights {
+		l := le.GetLight()
+		var direction math.Vector
+		var distance float64
+		var intensity color.Color
+		if area, ok := l.(core.AreaLight); ok {
+			samples := area.Samples()
+			if len(samples) == 0 {
+				continue
+			}
+			position := le.Transform().Apply(samples[rand.Intn(len(samples))]).AsPoint()
+			path := position.Sub(hit.OverPoint).AsVector()
+			direction, distance = path.Normalize(), path.Magnitude()
+			intensity = area.Intensity()
+		} else {
+			direction, distance = l.Towards(le, hit.OverPoint)
+			intensity = l.IntensityAt(le, hit.OverPoint)
+		}
+		if direction.Dot(hit.Normal) <= 0 {
+			continue
+		}
+		filter := s.Transmittance(hit.OverPoint, direction, distance)
+		if filter.Equal(color.Black) {
+			continue
+		}
+		c = c.Add(lighting.Direct(mat, surface, intensity.Mult(filter), direction, hit.EyeVector, hit.Normal))
+	}
+	for _, e := range s.Emitters() {
+		c = c.Add(emitterLight(s, e, mat, surface, hit, 1))
+	}
+	return c
+}
+
+// scatter picks the next ray of a path at random between a diffuse bounce,
+// a mirror reflection and a refraction, in proportion to how much light the
+// material sends each way. The weight corrects the throughput for that
+// choice, and delta tells whether the new ray is a reflection or refraction,
+// perturbed on rough materials, rather than a diffuse or microfacet bounce.
+// Materials sending out more light than they receive are scaled down so that
+// paths cannot gain energy. On materials with the microfacet model, the
+// diffuse bounce is replaced by a bounce off the microfacets.
+func scatter(mat core.Material, surface color.Color, hit *ray.Intersection) (next ray.Ray, weight color.Color, delta bool, ok bool) {
+	diffuse := mat.Diffuse()
+	if mat.Model() == core.Microfacet {
+		diffuse = 1
+	}
+	reflective := mat.Reflective()
+	transparency := mat.Transparency()
+	if reflective > 0 && transparency > 0 {
+		reflectance := hit.Schlick()
+		reflective *= reflectance
+		transparency *= 1 - reflectance
+	}
+	total := diffuse + reflective + transparency
+	if total <= 0 {
+		return ray.Ray{}, color.Black, false, false
+	}
+	// Each choice is weighted by its probability, so only the overall energy
+	// and the surface color are left in the weight.
+	energy := m.Min(total, 1)
+
+	choice := rand.Float64() * total
+	switch {
+	case choice < diffuse && mat.Model() == core.Microfacet:
+		next, weight, ok := microfacetScatter(mat, surface, hit)
+		return next, weight.Scale(energy), false, ok
+	case choice < diffuse:
+		return ray.NewRay(hit.OverPoint, cosineSample(hit.Normal)), surface.Scale(energy), false, true
+	case choice < diffuse+reflective:
+		direction := perturb(hit.ReflectVector, hit.Normal, mat.ReflectionRoughness())
+		return ray.NewRay(hit.OverPoint, direction), color.White.Scale(energy), true, true
+	}
+	if direction, ok := refraction(hit); ok {
+		direction = perturb(direction, hit.Normal, mat.RefractionRoughness())
+		return ray.NewRay(hit.UnderPoint, direction), color.White.Scale(energy), true, true
+	}
+	// Total internal reflection
+	return ray.NewRay(hit.OverPoint, hit.ReflectVector), color.White.Scale(energy), true, true
+}
+
+// microfacetScatter picks between a diffuse bounce and a reflection off a
+// microfacet, whose normal is drawn from the GGX distribution. Metals only
+// reflect off their microfacets.
+func microfacetScatter(mat core.Material, surface color.Color, hit *ray.Intersection) (ray.Ray, color.Color, bool) {
+	n, v := hit.Normal, hit.EyeVector
+	nv := v.Dot(n)
+	if nv <= 0 {
+		return ray.Ray{}, color.Black, false
+	}
+	f0 := lighting.SpecularColor(mat, surface)
+	metallic := mat.Metallic()
+	specular := 0.5 + 0.5*metallic
+
+	if rand.Float64() >= specular {
+		// Light not reflected by the microfacets, approximated at the angle
+		// of the eye
+		transmitted := color.White.Sub(lighting.FresnelSchlick(f0, nv))
+		weight := surface.Mult(transmitted).Scale((1 - metallic) / (1 - specular))
+		return ray.NewRay(hit.OverPoint, cosineSample(n)), weight, true
+	}
+
+	alpha := lighting.Alpha(mat.Roughness())
+	h := ggxSample(n, alpha)
+	vh := v.Dot(h)
+	direction := h.Scale(2 * vh).Sub(v).AsVector()
+	nl := direction.Dot(n)
+	if vh <= 0 || nl <= 0 {
+		// Reflected under the surface
+		return ray.Ray{}, color.Black, false
+	}
+	// BRDF * cos / pdf, where the pdf of the direction is D * (n.h) / (4 * v.h)
+	nh := h.Dot(n)
+	weight := lighting.FresnelSchlick(f0, vh).Scale(lighting.Smith(nl, nv, alpha) * vh / (nv * nh * specular))
+	return ray.NewRay(hit.OverPoint, direction), weight, true
+}
+
+// ggxSample picks a random microfacet normal around n, following the GGX
+// distribution weighted by the cosine with n.
+func ggxSample(n math.Vector, alpha float64) math.Vector {
+	a := math.NewVector(1, 0, 0)
+	if m.Abs(n.X()) > 0.9 {
+		a = math.NewVector(0, 1, 0)
+	}
+	t := n.Cross(a).Normalize()
+	b := n.Cross(t)
+
+	phi := 2 * m.Pi * rand.Float64()
+	xi := rand.Float64()
+	cos := m.Sqrt((1 - xi) / (1 + (alpha*alpha-1)*xi))
+	sin := m.Sqrt(1 - cos*cos)
+	return t.Scale(sin * m.Cos(phi)).
+		Add(b.Scale(sin * m.Sin(phi))).
+		Add(n.Scale(cos)).
+		AsVector().Normalize()
+}
+
+// cosineSample picks a random direction in the hemisphere around the normal,
+// more likely close to the normal in proportion to the cosine of the angle
+// with it, as light is reflected by perfectly diffuse surfaces.
+func cosineSample(n math.Vector) math.Vector {
+	a := math.NewVector(1, 0, 0)
+	if m.Abs(n.X()) > 0.9 {
+		a = math.NewVector(0, 1, 0)
+	}
+	t := n.Cross(a).Normalize()
+	b := n.Cross(t)
+
+	phi := 2 * m.Pi * rand.Float64()
+	r2 := rand.Float64()
+	radius := m.Sqrt(r2)
+	return t.Scale(radius * m.Cos(phi)).
+		Add(b.Scale(radius * m.Sin(phi))).
+		Add(n.Scale(m.Sqrt(1 - r2))).
+		AsVector().Normalize()
+}
@@ -0,0 +1,109 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/entities"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/material"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+	"github.com/bricef/ray-tracer/pkg/scene"
+)
+
+func TestPathTraceWhiteFurnace(t *testing.T) {
+	// A white diffuse sphere lit by a uniform white background reflects all
+	// of it, whichever way the path bounces off.
+	s := scene.NewScene()
+	s.BackgroundColor = color.White
+	s.Add(entities.NewSphere().AddComponent(
+		material.NewMaterial().SetDiffuse(1).SetSpecular(0),
+	))
+	r := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+	pt := scene.PathTracer{}
+	for i := 0; i < 100; i++ {
+		if c := pt.Radiance(s, r); !c.Equal(color.White) {
+			t.Fatalf("Expected the sphere to disappear in the furnace. Got %v", c)
+		}
+	}
+}
+
+func TestPathTraceDirectLightMatchesWhitted(t *testing.T) {
+	// Light bouncing off a lone plane escapes into the black background, so
+	// only direct light is left, without the Whitted ambient term.
+	s := scene.NewScene()
+	s.Add(lighting.NewPointLight(color.White).Translate(-2, 5, -3))
+	s.Add(entities.NewPlane())
+	r := ray.NewRay(math.NewPoint(0, 1, -5), math.NewVector(0, -1, 5).Normalize())
+
+	hit := s.Intersections(r).Hit
+	ambient := hit.Entity.GetMaterial().Ambient()
+	expected := scene.Whitted{}.Radiance(s, r).Sub(color.White.Scale(ambient))
+	pt := scene.PathTracer{}
+	for i := 0; i < 10; i++ {
+		if c := pt.Radiance(s, r); !c.Equal(expected) {
+			t.Fatalf("Expected direct light %v. Got %v", expected, c)
+		}
+	}
+}
+
+func TestPathTraceColourBleeding(t *testing.T) {
+	// A white floor next to a red wall picks up red light bounced off the
+	// wall.
+	s := scene.NewScene()
+	s.Add(lighting.NewPointLight(color.White).Translate(-1, 5, 0))
+	s.Add(entities.NewPlane().AddComponent(
+		material.NewMaterial().SetSpecular(0),
+	))
+	s.Add(entities.NewPlane().RotateZ(1.5707963267948966).AddComponent(
+		material.NewMaterial().SetColor(color.Red).SetSpecular(0),
+	))
+	s.Build()
+	r := ray.NewRay(math.NewPoint(-0.5, 1, -3), math.NewVector(0, -1, 3).Normalize())
+
+	sum := color.Black
+	for i := 0; i < 500; i++ {
+		sum = sum.Add(scene.PathTracer{}.Radiance(s, r))
+	}
+	c := sum.Scale(1.0 / 500)
+	if c.R <= c.G*1.05 || c.G != c.B {
+		t.Errorf("Expected red light bounced onto the white floor. Got %v", c)
+	}
+}
+
+func TestPathTraceRussianRouletteEndsClosedPaths(t *testing.T) {
+	// Inside a perfectly white sphere paths never escape, so they can only
+	// end by Russian roulette. There is no light to find.
+	s := scene.NewScene()
+	s.BackgroundColor = color.White
+	s.Add(entities.NewSphere().Scale(10, 10, 10).AddComponent(
+		material.NewMaterial().SetDiffuse(1).SetSpecular(0),
+	))
+	r := ray.NewRay(math.NewPoint(0, 0, 0), math.NewVector(0, 0, 1))
+	pt := scene.PathTracer{}
+	for i := 0; i < 100; i++ {
+		if c := pt.Radiance(s, r); !c.Equal(color.Black) {
+			t.Fatalf("Expected darkness in a closed sphere. Got %v", c)
+		}
+	}
+}
+
+func TestPathTraceMicrofacetFurnace(t *testing.T) {
+	// A white metal reflects nearly all of a uniform white background. Only
+	// light bouncing between microfacets more than once goes missing.
+	s := scene.NewScene()
+	s.BackgroundColor = color.White
+	s.Add(entities.NewSphere().AddComponent(
+		material.NewPBRMaterial().SetMetallic(1).SetRoughness(0.5),
+	))
+	r := ray.NewRay(math.NewPoint(0, 0, -5), math.NewVector(0, 0, 1))
+	total := color.Black
+	n := 2000
+	for i := 0; i < n; i++ {
+		total = total.Add(scene.PathTracer{}.Radiance(s, r))
+	}
+	if average := total.Scale(1 / float64(n)).R; average < 0.85 || average > 1.05 {
+		t.Errorf("Expected the metal sphere to nearly disappear in the furnace. Got %v", average)
+	}
+}
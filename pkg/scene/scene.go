@@ -2,7 +2,6 @@ package scene
 
 import (
 	"fmt"
-	m "math"
 	"strings"
 
 	"github.com/bricef/ray-tracer/pkg/color"
@@ -18,6 +17,8 @@ type Scene struct {
 	lights          []core.Entity
 	Entities        []core.Entity
 	BackgroundColor color.Color
+	emitters        []core.Entity
+	bvh             *ray.BVH
 }
 
 func (s *Scene) Lights() []core.Entity {
@@ -34,7 +35,54 @@ func (s *Scene) Add(o core.Entity) {
 	} else {
 		s.Entities = append(s.Entities, o)
 	}
+	s.bvh = nil
+}
 
+// Build validates the scene, then partitions the scene entities in a bounding
+// volume hierarchy used by Intersections, and collects the Emitters. It must
+// be called again if entities are moved or changed after. A scene failing
+// validation is left unbuilt.
+func (s *Scene) Build() error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	s.bvh = ray.NewBVH(s.Entities)
+	s.emitters = emitters(s.Entities, nil)
+	return nil
+}
+
+// Built returns a built copy of the scene sharing its entities, leaving the
+// scene itself untouched so that it can be rendered by several goroutines at
+// once.
+func (s *Scene) Built() (*Scene, error) {
+	built := *s
+	if err := built.Build(); err != nil {
+		return nil, err
+	}
+	return &built, nil
+}
+
+// Validate checks that every entity of the scene can be rendered. Entities
+// with a non invertible transform fail with a *math.NonInvertibleError.
+func (s *Scene) Validate() error {
+	for _, e := range append(append([]core.Entity{}, s.lights...), s.Entities...) {
+		if err := validate(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validate(e core.Entity) error {
+	if _, err := e.Transform().Invert(); err != nil {
+		return fmt.Errorf("entity %v: %w", e.Name(), err)
+	}
+	for _, c := range e.Children() {
+		if err := validate(c); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func DefaultScene() *Scene {
@@ -59,148 +107,61 @@ func DefaultScene() *Scene {
 }
 
 func (s *Scene) Intersections(r ray.Ray) *ray.Intersections {
+	if s.bvh != nil {
+		return r.GetIntersectionsBVH(s.bvh)
+	}
 	return r.GetIntersections(s.Entities)
 }
 
-func (s *Scene) Obstructed(a math.Point, b math.Point) bool {
-	path := a.Sub(b).AsVector()
-	distance := path.Magnitude()
-	direction := path.Normalize()
-	r := ray.NewRay(b, direction)
-	xs := s.Intersections(r)
-	if xs.Hit != nil && xs.Hit.T <= distance {
-		return true
-	}
-	return false
+// Obstructed reports whether the point is in full shadow from the light.
+func (s *Scene) Obstructed(p math.Point, le core.Entity) bool {
+	return s.Shadow(p, le).Equal(color.Black)
 }
 
-func (s *Scene) Cast(r ray.Ray) color.Color {
-	return s.LimitedCast(r, 5)
+// Shadow is the fraction of the light that reaches the point, per channel.
+// Transparent objects in the way let some of the light through.
+func (s *Scene) Shadow(p math.Point, le core.Entity) color.Color {
+	direction, distance := le.GetLight().Towards(le, p)
+	return s.Transmittance(p, direction, distance)
 }
 
-func (s *Scene) LimitedCast(r ray.Ray, depth int) color.Color {
-	if depth <= 0 { //Abort recursion after depth reached.
-		return color.Black
-	}
-
-	c := color.New(0, 0, 0)
-	xs := s.Intersections(r)
-	if xs.Hit != nil {
-
-		// Get lighting contributions
-		surface := s.LightingContribution(xs.Hit, depth)
-
-		// Get reflected contributions
-		reflected := s.ReflectedContribution(xs.Hit, depth)
-
-		// Get refracted contribution
-		refracted := s.RefractedContribution(xs.Hit, depth)
-
-		mat := xs.Hit.Entity.GetMaterial()
-		if (mat != nil) && (mat.Reflective() > 0.0) && (mat.Transparency() > 0.0) {
-			reflectance := xs.Hit.Schlick()
-			c = c.Add(surface).Add(
-				reflected.Scale(reflectance),
-			).Add(
-				refracted.Scale(1.0 - reflectance),
-			)
-		} else {
-			c = c.Add(surface).Add(reflected).Add(refracted)
+// Transmittance filters light travelling from the point along the direction
+// through every shadow casting entity closer than the distance. Each entity
+// attenuates the light once, however many of its surfaces the ray crosses.
+func (s *Scene) Transmittance(p math.Point, direction math.Vector, distance float64) color.Color {
+	xs := s.Intersections(ray.NewRay(p, direction))
+	filter := color.White
+	crossed := []core.Entity{}
+	for _, x := range xs.All {
+		if x.T <= 0 || x.T > distance || core.Contains(crossed, x.Entity) {
+			continue
 		}
+		crossed = append(crossed, x.Entity)
 
-		return c
+		mat := x.Entity.GetMaterial()
+		if !mat.CastsShadow() {
+			continue
+		}
+		if mat.Transparency() == 0 {
+			return color.Black
+		}
+		f := color.White.Scale(mat.Transparency())
+		if mat.TintsShadow() {
+			f = f.Mult(mat.ColorOn(x.Entity, x.Point))
+		}
+		filter = filter.Mult(f)
 	}
-	return s.BackgroundColor
+	return filter
 }
+
 func (s *Scene) Tick() *Scene {
 	for _, e := range s.Entities {
 		e.Tick(s.Entities)
 	}
+	s.bvh = nil
 	return s
 }
 
-func (s *Scene) LightingContribution(hit *ray.Intersection, depth int) color.Color {
-	c := color.New(0, 0, 0)
-	for _, l := range s.lights {
-		c = c.Add(s.LightContribution(l, hit))
-	}
-	return c
-}
-
-func (s *Scene) LightContribution(l core.Entity, hit *ray.Intersection) color.Color {
-	mat := hit.Entity.GetMaterial()
-	if s.Obstructed(hit.OverPoint, l.Position()) {
-		return lighting.PhongShadow(mat, l, hit.OverPoint, hit.EyeVector, hit.Normal)
-	} else {
-		return lighting.Phong(mat, l, hit.OverPoint, hit.EyeVector, hit.Normal)
-	}
-}
-
-func (s *Scene) ReflectedContribution(i *ray.Intersection, depth int) color.Color {
-	mat := i.Entity.GetMaterial()
-	if mat == nil { // No material
-		return color.Black
-	}
-
-	if mat.Reflective() == 0.0 { // Not reflective
-		return color.Black
-	}
-
-	r := ray.NewRay(
-		i.OverPoint,
-		i.ReflectVector,
-	)
-	return s.LimitedCast(r, depth-1).Scale(mat.Reflective())
-
-}
-
-func (s *Scene) RefractedContribution(i *ray.Intersection, depth int) color.Color {
-	mat := i.Entity.GetMaterial()
-	// Max depth, no refraction
-	if depth <= 0 {
-		return color.Black
-	}
-
-	// No material, no refraction
-	if mat == nil {
-		return color.Black
-	}
-
-	// Material is opaque, no refraction
-	if mat.Transparency() == 0.0 {
-		return color.Black
-	}
-
-	r := i.N1 / i.N2
-	cos_i := i.EyeVector.Dot(i.Normal)
-	sin2_t := r * r * (1 - (cos_i * cos_i))
-	if sin2_t > 1.0 {
-		return color.Black
-	}
-
-	// FROM BOOK
-	cos_t := m.Sqrt(1.0 - sin2_t)
-	direction := i.Normal.Scale((r * cos_i) - cos_t).Sub(i.EyeVector.Scale(r))
-
-	// FROM WIKIPEDIA https://en.wikipedia.org/wiki/Snell%27s_law
-	// c := i.Normal.Negate().AsVector().Dot(i.EyeVector)
-	// direction := i.EyeVector.Scale(r).Add(
-	// 	i.Normal.Scale(
-	// 		(r * c) - m.Sqrt(
-	// 			1-(r*r)-(r*r*c*c),
-	// 		),
-	// 	),
-	// )
-
-	refractionRay := ray.NewRay(
-		i.UnderPoint,
-		direction.AsVector(),
-	)
-	return s.LimitedCast(refractionRay, depth-1).Scale(mat.Transparency())
-
-	// return color.White?
-}
-
 const TABWIDTH int = 4
 
 func display(e core.Entity, level int) {
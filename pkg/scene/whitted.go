@@ -0,0 +1,247 @@
+package scene
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/lighting"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/ray"
+)
+
+// Defaults of the Whitted integrator
+const (
+	defaultDepth          = 5
+	defaultEmitterSamples = 16
+	defaultGlossySamples  = 8
+)
+
+// Whitted traces mirror reflection and refraction recursively, with Phong
+// shading and a constant ambient term standing in for indirect light.
+type Whitted struct {
+	// Number of bounces followed. Zero uses 5.
+	Depth int
+	// Points sampled on each emitter of the scene. Zero uses 16.
+	EmitterSamples int
+	// Rays cast around rough reflections and refractions. Only the first
+	// rough surface along a path casts several, later ones cast a single ray
+	// each. Zero uses 8.
+	GlossySamples int
+}
+
+func (w Whitted) Radiance(s *Scene, r ray.Ray) color.Color {
+	depth := w.Depth
+	if depth <= 0 {
+		depth = defaultDepth
+	}
+	return w.trace(s, r, depth, w.glossySamples())
+}
+
+func (w Whitted) glossySamples() int {
+	if w.GlossySamples <= 0 {
+		return defaultGlossySamples
+	}
+	return w.GlossySamples
+}
+
+// trace follows the ray for the given number of bounces, casting the given
+// number of rays around rough reflections and refractions.
+func (w Whitted) trace(s *Scene, r ray.Ray, depth int, samples int) color.Color {
+	if depth <= 0 { //Abort recursion after depth reached.
+		return color.Black
+	}
+
+	c := color.New(0, 0, 0)
+	xs := s.Intersections(r)
+	if xs.Hit != nil {
+
+		// Get lighting contributions
+		surface := w.LightingContribution(s, xs.Hit)
+
+		// Get reflected contributions
+		reflected := w.reflected(s, xs.Hit, depth, samples)
+
+		// Get refracted contribution
+		refracted := w.refracted(s, xs.Hit, depth, samples)
+
+		mat := xs.Hit.Entity.GetMaterial()
+		if (mat != nil) && (mat.Reflective() > 0.0) && (mat.Transparency() > 0.0) {
+			reflectance := xs.Hit.Schlick()
+			c = c.Add(surface).Add(
+				reflected.Scale(reflectance),
+			).Add(
+				refracted.Scale(1.0 - reflectance),
+			)
+		} else {
+			c = c.Add(surface).Add(reflected).Add(refracted)
+		}
+
+		return c.Add(emission(xs.Hit))
+	}
+	return s.BackgroundColor
+}
+
+// LightingContribution is the light from all the lights and emitters of the
+// scene reflected by the hit surface.
+func (w Whitted) LightingContribution(s *Scene, hit *ray.Intersection) color.Color {
+	c := color.New(0, 0, 0)
+	for _, l := range s.Lights() {
+		c = c.Add(w.LightContribution(s, l, hit))
+	}
+	return c.Add(w.EmissiveContribution(s, hit))
+}
+
+// EmissiveContribution is the light from all the emitters of the scene
+// reflected by the hit surface, sampled at EmitterSamples points on each.
+func (w Whitted) EmissiveContribution(s *Scene, hit *ray.Intersection) color.Color {
+	mat := hit.Entity.GetMaterial()
+	if mat == nil {
+		return color.Black
+	}
+	samples := w.EmitterSamples
+	if samples <= 0 {
+		samples = defaultEmitterSamples
+	}
+	surface := mat.ColorOn(hit.Entity, hit.Point)
+	c := color.Black
+	for _, e := range s.Emitters() {
+		c = c.Add(emitterLight(s, e, mat, surface, hit, samples))
+	}
+	return c
+}
+
+// LightContribution is the light from the light entity reflected by the hit
+// surface. Surfaces without material are not lit.
+func (w Whitted) LightContribution(s *Scene, l core.Entity, hit *ray.Intersection) color.Color {
+	mat := hit.Entity.GetMaterial()
+	if mat == nil || l.GetLight() == nil {
+		return color.Black
+	}
+	// Shading cannot fail past this point, so errors are ignored
+	if _, ok := l.GetLight().(core.AreaLight); ok {
+		c, _ := lighting.PhongArea(mat, l, hit.OverPoint, hit.EyeVector, hit.Normal, func(sample math.Point) color.Color {
+			path := sample.Sub(hit.OverPoint).AsVector()
+			return s.Transmittance(hit.OverPoint, path.Normalize(), path.Magnitude())
+		})
+		return c
+	}
+	filter := s.Shadow(hit.OverPoint, l)
+	ambient, _ := lighting.PhongShadow(mat, l, hit.OverPoint, hit.EyeVector, hit.Normal)
+	if filter.Equal(color.Black) {
+		return ambient
+	}
+	lit, _ := lighting.Phong(mat, l, hit.OverPoint, hit.EyeVector, hit.Normal)
+	if filter.Equal(color.White) {
+		return lit
+	}
+	// Only the direct lighting goes through transparent occluders
+	return ambient.Add(lit.Sub(ambient).Mult(filter))
+}
+
+// ReflectedContribution is the light reflected by the hit surface. Rough
+// reflections average rays scattered around the mirror direction, blurring
+// the reflected scene as on brushed metal.
+func (w Whitted) ReflectedContribution(s *Scene, i *ray.Intersection, depth int) color.Color {
+	return w.reflected(s, i, depth, w.glossySamples())
+}
+
+func (w Whitted) reflected(s *Scene, i *ray.Intersection, depth int, samples int) color.Color {
+	mat := i.Entity.GetMaterial()
+	if mat == nil { // No material
+		return color.Black
+	}
+
+	if mat.Reflective() == 0.0 { // Not reflective
+		return color.Black
+	}
+
+	roughness := mat.ReflectionRoughness()
+	if roughness == 0 {
+		r := ray.NewRay(
+			i.OverPoint,
+			i.ReflectVector,
+		)
+		return w.trace(s, r, depth-1, samples).Scale(mat.Reflective())
+	}
+	c := color.Black
+	for k := 0; k < samples; k++ {
+		r := ray.NewRay(i.OverPoint, perturb(i.ReflectVector, i.Normal, roughness))
+		c = c.Add(w.trace(s, r, depth-1, 1))
+	}
+	return c.Scale(mat.Reflective() / float64(samples))
+}
+
+// RefractedContribution is the light coming through the hit surface. Rough
+// refractions average rays scattered around the refracted direction, blurring
+// the scene behind as frosted glass does.
+func (w Whitted) RefractedContribution(s *Scene, i *ray.Intersection, depth int) color.Color {
+	return w.refracted(s, i, depth, w.glossySamples())
+}
+
+func (w Whitted) refracted(s *Scene, i *ray.Intersection, depth int, samples int) color.Color {
+	mat := i.Entity.GetMaterial()
+	// Max depth, no refraction
+	if depth <= 0 {
+		return color.Black
+	}
+
+	// No material, no refraction
+	if mat == nil {
+		return color.Black
+	}
+
+	// Material is opaque, no refraction
+	if mat.Transparency() == 0.0 {
+		return color.Black
+	}
+
+	direction, ok := refraction(i)
+	if !ok { // Total internal reflection
+		return color.Black
+	}
+
+	roughness := mat.RefractionRoughness()
+	if roughness == 0 {
+		refractionRay := ray.NewRay(
+			i.UnderPoint,
+			direction,
+		)
+		return w.trace(s, refractionRay, depth-1, samples).Scale(mat.Transparency())
+	}
+	c := color.Black
+	for k := 0; k < samples; k++ {
+		r := ray.NewRay(i.UnderPoint, perturb(direction, i.Normal, roughness))
+		c = c.Add(w.trace(s, r, depth-1, 1))
+	}
+	return c.Scale(mat.Transparency() / float64(samples))
+
+	// return color.White?
+}
+
+// refraction is the direction of the ray bent through the hit surface, unless
+// it is totally reflected.
+func refraction(i *ray.Intersection) (math.Vector, bool) {
+	r := i.N1 / i.N2
+	cos_i := i.EyeVector.Dot(i.Normal)
+	sin2_t := r * r * (1 - (cos_i * cos_i))
+	if sin2_t > 1.0 {
+		return nil, false
+	}
+
+	// FROM BOOK
+	cos_t := m.Sqrt(1.0 - sin2_t)
+	direction := i.Normal.Scale((r * cos_i) - cos_t).Sub(i.EyeVector.Scale(r))
+
+	// FROM WIKIPEDIA https://en.wikipedia.org/wiki/Snell%27s_law
+	// c := i.Normal.Negate().AsVector().Dot(i.EyeVector)
+	// direction := i.EyeVector.Scale(r).Add(
+	// 	i.Normal.Scale(
+	// 		(r * c) - m.Sqrt(
+	// 			1-(r*r)-(r*r*c*c),
+	// 		),
+	// 	),
+	// )
+
+	return direction.AsVector(), true
+}
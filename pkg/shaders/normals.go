@@ -0,0 +1,92 @@
+package shaders
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+	opensimplex "github.com/ojrac/opensimplex-go"
+)
+
+// Height is the elevation of a bumpy surface above a point, in object space.
+type Height func(p math.Point) float64
+
+// Step used to differentiate heights and mappings
+const delta = 1e-4
+
+// tangents returns two unit vectors perpendicular to n and to each other.
+func tangents(n math.Vector) (math.Vector, math.Vector) {
+	a := math.NewVector(1, 0, 0)
+	if m.Abs(n.X()) > 0.9 {
+		a = math.NewVector(0, 1, 0)
+	}
+	t := n.Cross(a).Normalize()
+	return t, n.Cross(t)
+}
+
+// Bump tilts the normal as if the surface were raised by the height function,
+// scaled by strength.
+func Bump(h Height, strength float64) core.NormalMap {
+	return func(p math.Point, n math.Vector) math.Vector {
+		// Only the slope along the surface tilts the normal
+		t, b := tangents(n)
+		dt := (h(p.Add(t.Scale(delta))) - h(p.Sub(t.Scale(delta)))) / (2 * delta)
+		db := (h(p.Add(b.Scale(delta))) - h(p.Sub(b.Scale(delta)))) / (2 * delta)
+		return n.Sub(t.Scale(dt * strength)).Sub(b.Scale(db * strength)).AsVector().Normalize()
+	}
+}
+
+// Noise is a random, smooth height in [-1, 1] with features about 1 / scale
+// units across.
+func Noise(scale float64) Height {
+	noise := opensimplex.New(rand.Int63())
+	return func(p math.Point) float64 {
+		return noise.Eval3(p.X()*scale, p.Y()*scale, p.Z()*scale)
+	}
+}
+
+// TangentNormals perturbs the normal with a tangent space normal map, as
+// exported by most 3D tools: red and green tilt the normal towards
+// increasing u and v, blue is along the normal. The texture must hold linear
+// values, see NewDataTexture.
+func TangentNormals(s UVShader, mapping UVMapping) core.NormalMap {
+	return func(p math.Point, n math.Vector) math.Vector {
+		tangent, bitangent, ok := uvTangents(mapping, p, n)
+		if !ok {
+			return n
+		}
+		c := s(mapping(p))
+		x, y, z := 2*c.R-1, 2*c.G-1, 2*c.B-1
+		return tangent.Scale(x).Add(bitangent.Scale(y)).Add(n.Scale(z)).AsVector().Normalize()
+	}
+}
+
+// uvTangents finds the directions along the surface in which u and v
+// increase, by differentiating the mapping. They are made orthonormal to n.
+func uvTangents(mapping UVMapping, p math.Point, n math.Vector) (math.Vector, math.Vector, bool) {
+	a, b := tangents(n)
+	u0, v0 := mapping(p)
+	ua, va := mapping(p.Add(a.Scale(delta)))
+	ub, vb := mapping(p.Add(b.Scale(delta)))
+	// Differences across the seam of a wrapping mapping
+	seam := func(d float64) float64 {
+		return d - m.Round(d)
+	}
+	dua, dva := seam(ua-u0), seam(va-v0)
+	dub, dvb := seam(ub-u0), seam(vb-v0)
+
+	det := dua*dvb - dub*dva
+	if m.Abs(det) < delta*delta*1e-6 {
+		return n, n, false
+	}
+	dpdu := a.Scale(dvb / det).Sub(b.Scale(dva / det)).AsVector()
+	dpdv := b.Scale(dua / det).Sub(a.Scale(dub / det)).AsVector()
+
+	tangent := dpdu.Sub(n.Scale(n.Dot(dpdu))).AsVector().Normalize()
+	bitangent := n.Cross(tangent)
+	if bitangent.Dot(dpdv) < 0 {
+		bitangent = bitangent.Invert()
+	}
+	return tangent, bitangent, true
+}
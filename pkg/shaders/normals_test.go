@@ -0,0 +1,88 @@
+package shaders
+
+import (
+	m "math"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+func TestBumpOnFlatHeightKeepsNormal(t *testing.T) {
+	bump := Bump(func(p math.Point) float64 { return 3 }, 1)
+	n := math.NewVector(0, 1, 0)
+	if got := bump(math.NewPoint(1, 0, 2), n); !got.Equal(n) {
+		t.Errorf("Expected a flat height to keep the normal. Got %v", got)
+	}
+}
+
+func TestBumpTiltsNormalAwayFromSlope(t *testing.T) {
+	// Surface rising towards +x
+	bump := Bump(func(p math.Point) float64 { return p.X() }, 0.5)
+	got := bump(math.NewPoint(0, 0, 0), math.NewVector(0, 1, 0))
+	expected := math.NewVector(-0.5, 1, 0).Normalize()
+	if !got.Equal(expected) {
+		t.Errorf("Expected normal tilted away from the slope %v. Got %v", expected, got)
+	}
+}
+
+func TestNoiseBumpStaysUnitLength(t *testing.T) {
+	bump := Bump(Noise(4), 0.3)
+	n := math.NewVector(0, 0, -1)
+	changed := false
+	for i := 0; i < 10; i++ {
+		got := bump(math.NewPoint(float64(i)*0.37, 0.1, 0), n)
+		if m.Abs(got.Magnitude()-1) > 1e-9 {
+			t.Errorf("Bumped normal isn't normalised: %v", got)
+		}
+		if got.Dot(n) <= 0 {
+			t.Errorf("Bumped normal %v flipped over", got)
+		}
+		if !got.Equal(n) {
+			changed = true
+		}
+	}
+	if !changed {
+		t.Errorf("Expected noise to perturb the normal")
+	}
+}
+
+func TestFlatNormalMapKeepsNormal(t *testing.T) {
+	flat := func(u, v float64) color.Color { return color.New(0.5, 0.5, 1) }
+	normals := TangentNormals(flat, Spherical)
+	p := math.NewPoint(0, 0, -1)
+	n := math.NewVector(0, 0, -1)
+	if got := normals(p, n); !got.Equal(n) {
+		t.Errorf("Expected a flat normal map to keep the normal. Got %v", got)
+	}
+}
+
+func TestNormalMapFollowsTextureDirections(t *testing.T) {
+	tests := []struct {
+		c        color.Color
+		expected math.Vector
+	}{
+		// Towards increasing u, which goes around the sphere towards +x
+		{color.New(1, 0.5, 0.5), math.NewVector(1, 0, 0)},
+		// Towards increasing v, which goes up to the north pole
+		{color.New(0.5, 1, 0.5), math.NewVector(0, 1, 0)},
+	}
+	for _, test := range tests {
+		c := test.c
+		normals := TangentNormals(func(u, v float64) color.Color { return c }, Spherical)
+		got := normals(math.NewPoint(0, 0, -1), math.NewVector(0, 0, -1))
+		if !got.Equal(test.expected) {
+			t.Errorf("Normal map color %v. Expected %v, got %v", c, test.expected, got)
+		}
+	}
+}
+
+func TestNormalMapAcrossSeam(t *testing.T) {
+	// The planar mapping wraps at every integer
+	up := func(u, v float64) color.Color { return color.New(0.5, 1, 0.5) }
+	normals := TangentNormals(up, Planar)
+	got := normals(math.NewPoint(1, 0, 1), math.NewVector(0, 1, 0))
+	if !got.Equal(math.NewVector(0, 0, 1)) {
+		t.Errorf("Expected normal tilted towards +z at the seam. Got %v", got)
+	}
+}
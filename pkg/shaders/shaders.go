@@ -11,10 +11,16 @@ import (
 	opensimplex "github.com/ojrac/opensimplex-go"
 )
 
-func With(t math.Transform, s core.Shader) core.Shader {
-	return func(p math.Point) color.Color {
-		return s(t.Inverse().Apply(p))
+// With moves the shader by the transform. Non invertible transforms fail with
+// a *math.NonInvertibleError.
+func With(t math.Transform, s core.Shader) (core.Shader, error) {
+	inverse, err := t.Invert()
+	if err != nil {
+		return nil, err
 	}
+	return func(p math.Point) color.Color {
+		return s(inverse.Apply(p))
+	}, nil
 }
 
 func Pigment(c color.Color) core.Shader {
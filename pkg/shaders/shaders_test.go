@@ -1,6 +1,7 @@
 package shaders
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/bricef/ray-tracer/pkg/color"
@@ -145,3 +146,11 @@ func TestBlendShader(t *testing.T) {
 	}
 
 }
+
+func TestWithRejectsNonInvertibleTransforms(t *testing.T) {
+	_, err := With(math.Scale(1, 0, 1), Test())
+	var ierr *math.NonInvertibleError
+	if !errors.As(err, &ierr) {
+		t.Errorf("Expected a non invertible error for a flattening transform. Got %v", err)
+	}
+}
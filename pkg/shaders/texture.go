@@ -0,0 +1,157 @@
+package shaders
+
+import (
+	"image"
+	// Decoders for LoadImage
+	_ "image/jpeg"
+	_ "image/png"
+	m "math"
+	"os"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+)
+
+// TextureFilter decides how a texture is sampled between texel centers.
+type TextureFilter int
+
+const (
+	// Nearest takes the texel under the point, giving sharp, blocky
+	// magnification.
+	Nearest TextureFilter = iota
+	// Bilinear blends the four texels around the point.
+	Bilinear
+)
+
+// WrapMode decides how a texture is sampled outside of [0, 1].
+type WrapMode int
+
+const (
+	// Repeat tiles the texture.
+	Repeat WrapMode = iota
+	// ClampToEdge stretches the edge texels.
+	ClampToEdge
+	// Mirror tiles the texture, flipping every other copy.
+	Mirror
+)
+
+// Texture is an image decoded to linear colors, ready for sampling.
+type Texture struct {
+	width  int
+	height int
+	// Rows from top to bottom, as in the image
+	texels [][]color.Color
+}
+
+// NewTexture decodes an image, taking its channels to be sRGB encoded as most
+// images are.
+func NewTexture(img image.Image) *Texture {
+	return newTexture(img, color.DecodeSRGB)
+}
+
+// NewDataTexture keeps the channels of an image as they are, for images
+// holding data rather than colors such as normal maps.
+func NewDataTexture(img image.Image) *Texture {
+	return newTexture(img, func(v float64) float64 { return v })
+}
+
+func newTexture(img image.Image, decode func(float64) float64) *Texture {
+	bounds := img.Bounds()
+	t := &Texture{
+		width:  bounds.Dx(),
+		height: bounds.Dy(),
+		texels: make([][]color.Color, bounds.Dy()),
+	}
+	for y := range t.texels {
+		t.texels[y] = make([]color.Color, t.width)
+		for x := range t.texels[y] {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			t.texels[y][x] = color.New(decode(float64(r)/0xffff), decode(float64(g)/0xffff), decode(float64(b)/0xffff))
+		}
+	}
+	return t
+}
+
+// LoadTexture reads a PNG or JPEG image file of sRGB colors.
+func LoadTexture(filename string) (*Texture, error) {
+	img, err := loadImage(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewTexture(img), nil
+}
+
+// LoadDataTexture reads a PNG or JPEG image file of linear data.
+func LoadDataTexture(filename string) (*Texture, error) {
+	img, err := loadImage(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewDataTexture(img), nil
+}
+
+func loadImage(filename string) (image.Image, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
+
+// wrapIndex brings a texel index into [0, n).
+func wrapIndex(i, n int, mode WrapMode) int {
+	switch mode {
+	case ClampToEdge:
+		if i < 0 {
+			return 0
+		}
+		if i >= n {
+			return n - 1
+		}
+		return i
+	case Mirror:
+		i = ((i % (2 * n)) + 2*n) % (2 * n)
+		if i >= n {
+			return 2*n - 1 - i
+		}
+		return i
+	}
+	return ((i % n) + n) % n
+}
+
+// nearest is the index of the texel covering the texture coordinate, across
+// a texture n texels wide. The far edge at 1 belongs to the last texel rather
+// than wrapping around to the first.
+func nearest(c float64, n int) int {
+	i := int(m.Floor(c * float64(n)))
+	if i == n && c <= 1 {
+		return n - 1
+	}
+	return i
+}
+
+// texel looks up a texel with rows counted from the bottom.
+func (t *Texture) texel(x, y int, mode WrapMode) color.Color {
+	x = wrapIndex(x, t.width, mode)
+	y = wrapIndex(y, t.height, mode)
+	return t.texels[t.height-1-y][x]
+}
+
+// Shader samples the texture, with v = 0 at the bottom of the image.
+func (t *Texture) Shader(filter TextureFilter, mode WrapMode) UVShader {
+	return func(u, v float64) color.Color {
+		if filter == Nearest {
+			return t.texel(nearest(u, t.width), nearest(v, t.height), mode)
+		}
+		// Texel centers are at half integer coordinates
+		x := u*float64(t.width) - 0.5
+		y := v*float64(t.height) - 0.5
+		x0, y0 := m.Floor(x), m.Floor(y)
+		fx, fy := x-x0, y-y0
+		i, j := int(x0), int(y0)
+		bottom := t.texel(i, j, mode).Scale(1 - fx).Add(t.texel(i+1, j, mode).Scale(fx))
+		top := t.texel(i, j+1, mode).Scale(1 - fx).Add(t.texel(i+1, j+1, mode).Scale(fx))
+		return bottom.Scale(1 - fy).Add(top.Scale(fy))
+	}
+}
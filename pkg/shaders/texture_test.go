@@ -0,0 +1,129 @@
+package shaders
+
+import (
+	"image"
+	imageColor "image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+)
+
+// testImage is 2x2 pixels, red and green on the top row, blue and white on
+// the bottom one.
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, imageColor.NRGBA{255, 0, 0, 255})
+	img.Set(1, 0, imageColor.NRGBA{0, 255, 0, 255})
+	img.Set(0, 1, imageColor.NRGBA{0, 0, 255, 255})
+	img.Set(1, 1, imageColor.NRGBA{255, 255, 255, 255})
+	return img
+}
+
+func TestNearestTexture(t *testing.T) {
+	s := NewTexture(testImage()).Shader(Nearest, Repeat)
+	tests := []struct {
+		u, v     float64
+		expected color.Color
+	}{
+		{0.25, 0.75, color.Red},
+		{0.75, 0.75, color.Green},
+		{0.25, 0.25, color.Blue},
+		{0.75, 0.25, color.White},
+		{1.25, -0.75, color.Blue},
+	}
+	for _, test := range tests {
+		if c := s(test.u, test.v); !c.Equal(test.expected) {
+			t.Errorf("Texture at (%v, %v). Expected %v, got %v", test.u, test.v, test.expected, c)
+		}
+	}
+}
+
+func TestNearestTextureEdges(t *testing.T) {
+	// The edges belong to the texels along them, whichever the wrap mode.
+	for _, mode := range []WrapMode{Repeat, ClampToEdge, Mirror} {
+		s := NewTexture(testImage()).Shader(Nearest, mode)
+		tests := []struct {
+			u, v     float64
+			expected color.Color
+		}{
+			{0, 0, color.Blue},
+			{0, 0.75, color.Red},
+			{0.75, 0, color.White},
+			{1, 1, color.Green},
+		}
+		for _, test := range tests {
+			if c := s(test.u, test.v); !c.Equal(test.expected) {
+				t.Errorf("Texture at (%v, %v) with wrap mode %v. Expected %v, got %v", test.u, test.v, mode, test.expected, c)
+			}
+		}
+	}
+}
+
+func TestBilinearTexture(t *testing.T) {
+	s := NewTexture(testImage()).Shader(Bilinear, ClampToEdge)
+	if c := s(0.5, 0.5); !c.Equal(color.New(0.5, 0.5, 0.5)) {
+		t.Errorf("Expected the center to blend all four texels. Got %v", c)
+	}
+	if c := s(0.5, 0.75); !c.Equal(color.New(0.5, 0.5, 0)) {
+		t.Errorf("Expected the top edge to blend red and green. Got %v", c)
+	}
+	if c := s(0, 1); !c.Equal(color.Red) {
+		t.Errorf("Expected the corner to clamp to red. Got %v", c)
+	}
+}
+
+func TestTextureWrapModes(t *testing.T) {
+	tests := []struct {
+		mode     WrapMode
+		expected []int
+	}{
+		{Repeat, []int{1, 2, 0, 1, 2, 0, 1}},
+		{ClampToEdge, []int{0, 0, 0, 1, 2, 2, 2}},
+		{Mirror, []int{1, 0, 0, 1, 2, 2, 1}},
+	}
+	for _, test := range tests {
+		for i, expected := range test.expected {
+			if got := wrapIndex(i-2, 3, test.mode); got != expected {
+				t.Errorf("Wrap mode %v of index %v. Expected %v, got %v", test.mode, i-2, expected, got)
+			}
+		}
+	}
+}
+
+func TestTextureDecodesSRGB(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, imageColor.NRGBA{95, 158, 160, 255})
+	if c := NewTexture(img).Shader(Nearest, Repeat)(0.5, 0.5); !c.Equal(color.Hex(0x5f9ea0)) {
+		t.Errorf("Expected texels decoded from sRGB. Got %v", c)
+	}
+}
+
+func TestLoadTexture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shaders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "texture.png")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, testImage())
+	f.Close()
+
+	texture, err := LoadTexture(filename)
+	if err != nil {
+		t.Fatalf("Failed to load texture: %v", err)
+	}
+	if c := texture.Shader(Nearest, Repeat)(0.75, 0.75); !c.Equal(color.Green) {
+		t.Errorf("Loaded texture has wrong texels. Got %v", c)
+	}
+	if _, err := LoadTexture(filepath.Join(dir, "missing.png")); err == nil {
+		t.Errorf("Expected loading a missing texture to fail")
+	}
+}
@@ -0,0 +1,154 @@
+package shaders
+
+import (
+	m "math"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/core"
+	"github.com/bricef/ray-tracer/pkg/math"
+)
+
+// UVShader colors a point of a two dimensional texture. u goes left to
+// right and v bottom to top, both over [0, 1].
+type UVShader func(u, v float64) color.Color
+
+// UVMapping flattens a point on the surface of an object, in object space,
+// to texture coordinates.
+type UVMapping func(p math.Point) (u, v float64)
+
+// UV wraps a texture around an object with a mapping.
+func UV(s UVShader, mapping UVMapping) core.Shader {
+	return func(p math.Point) color.Color {
+		return s(mapping(p))
+	}
+}
+
+// UVCheckers is a checkerboard of width x height squares, useful to check
+// how a mapping distorts a texture.
+func UVCheckers(width, height int, a, b color.Color) UVShader {
+	return func(u, v float64) color.Color {
+		if (int(m.Floor(u*float64(width)))+int(m.Floor(v*float64(height))))%2 == 0 {
+			return a
+		}
+		return b
+	}
+}
+
+// wrap brings a value into [0, 1), repeating it every unit.
+func wrap(v float64) float64 {
+	v = m.Mod(v, 1)
+	if v < 0 {
+		v++
+	}
+	return v
+}
+
+// Spherical maps a unit sphere, with u going around the y axis from -z and
+// v going from the south to the north pole.
+func Spherical(p math.Point) (float64, float64) {
+	theta := m.Atan2(p.X(), p.Z())
+	radius := math.NewVector(p.X(), p.Y(), p.Z()).Magnitude()
+	phi := m.Acos(p.Y() / radius)
+	u := 1 - (theta/(2*m.Pi) + 0.5)
+	v := 1 - phi/m.Pi
+	return u, v
+}
+
+// Planar tiles the xz plane with the texture, once per unit square.
+func Planar(p math.Point) (float64, float64) {
+	return wrap(p.X()), wrap(p.Z())
+}
+
+// Cylindrical wraps the texture once around the unit cylinder, repeating it
+// every unit along the y axis.
+func Cylindrical(p math.Point) (float64, float64) {
+	theta := m.Atan2(p.X(), p.Z())
+	u := 1 - (theta/(2*m.Pi) + 0.5)
+	return wrap(u), wrap(p.Y())
+}
+
+// CubeFace is a face of the unit cube.
+type CubeFace int
+
+const (
+	Left CubeFace = iota
+	Front
+	Right
+	Back
+	Up
+	Down
+)
+
+// FaceOf finds the face of the unit cube a point is on.
+func FaceOf(p math.Point) CubeFace {
+	x, y, z := p.X(), p.Y(), p.Z()
+	coord := m.Max(m.Abs(x), m.Max(m.Abs(y), m.Abs(z)))
+	switch coord {
+	case x:
+		return Right
+	case -x:
+		return Left
+	case y:
+		return Up
+	case -y:
+		return Down
+	case z:
+		return Front
+	}
+	return Back
+}
+
+// CubeFaceUV maps a point on a face of the unit cube to the whole texture,
+// seen from outside the cube with up towards +y, or towards -z on the top
+// and bottom faces.
+func CubeFaceUV(face CubeFace, p math.Point) (float64, float64) {
+	half := func(v float64) float64 {
+		return m.Mod(v, 2.0) / 2
+	}
+	x, y, z := p.X(), p.Y(), p.Z()
+	switch face {
+	case Front:
+		return half(x + 1), half(y + 1)
+	case Back:
+		return half(1 - x), half(y + 1)
+	case Left:
+		return half(z + 1), half(y + 1)
+	case Right:
+		return half(1 - z), half(y + 1)
+	case Up:
+		return half(x + 1), half(1 - z)
+	}
+	return half(x + 1), half(z + 1)
+}
+
+// CubeFaces gives each face of the unit cube its own texture.
+func CubeFaces(left, front, right, back, up, down UVShader) core.Shader {
+	faces := [6]UVShader{left, front, right, back, up, down}
+	return func(p math.Point) color.Color {
+		face := FaceOf(p)
+		return faces[face](CubeFaceUV(face, p))
+	}
+}
+
+// cross is the position of each face in a texture laid out as an unfolded
+// cube, four faces wide and three high:
+//
+//	    up
+//	left front right back
+//	    down
+var cross = [6][2]float64{
+	Left:  {0, 1},
+	Front: {1, 1},
+	Right: {2, 1},
+	Back:  {3, 1},
+	Up:    {1, 2},
+	Down:  {1, 0},
+}
+
+// Cubic maps the unit cube to a single texture laid out as an unfolded
+// cross, as used by skybox images.
+func Cubic(p math.Point) (float64, float64) {
+	face := FaceOf(p)
+	u, v := CubeFaceUV(face, p)
+	return (cross[face][0] + u) / 4, (cross[face][1] + v) / 3
+}
@@ -0,0 +1,142 @@
+package shaders
+
+import (
+	m "math"
+	"testing"
+
+	"github.com/bricef/ray-tracer/pkg/color"
+	"github.com/bricef/ray-tracer/pkg/math"
+	"github.com/bricef/ray-tracer/pkg/utils"
+)
+
+type UVTest struct {
+	point math.Point
+	u, v  float64
+}
+
+func checkMapping(t *testing.T, name string, mapping UVMapping, tests []UVTest) {
+	for _, test := range tests {
+		u, v := mapping(test.point)
+		if !utils.AlmostEqual(u, test.u) || !utils.AlmostEqual(v, test.v) {
+			t.Errorf("%v mapping of %v. Expected (%v, %v), got (%v, %v)", name, test.point, test.u, test.v, u, v)
+		}
+	}
+}
+
+func TestUVCheckers(t *testing.T) {
+	checkers := UVCheckers(2, 2, color.Black, color.White)
+	tests := []struct {
+		u, v     float64
+		expected color.Color
+	}{
+		{0, 0, color.Black},
+		{0.5, 0, color.White},
+		{0, 0.5, color.White},
+		{0.5, 0.5, color.Black},
+		{1, 1, color.Black},
+	}
+	for _, test := range tests {
+		if c := checkers(test.u, test.v); !c.Equal(test.expected) {
+			t.Errorf("Checkers at (%v, %v). Expected %v, got %v", test.u, test.v, test.expected, c)
+		}
+	}
+}
+
+func TestSphericalMapping(t *testing.T) {
+	checkMapping(t, "Spherical", Spherical, []UVTest{
+		{math.NewPoint(0, 0, -1), 0, 0.5},
+		{math.NewPoint(1, 0, 0), 0.25, 0.5},
+		{math.NewPoint(0, 0, 1), 0.5, 0.5},
+		{math.NewPoint(-1, 0, 0), 0.75, 0.5},
+		{math.NewPoint(0, 1, 0), 0.5, 1},
+		{math.NewPoint(0, -1, 0), 0.5, 0},
+		{math.NewPoint(m.Sqrt2/2, m.Sqrt2/2, 0), 0.25, 0.75},
+	})
+}
+
+func TestPlanarMapping(t *testing.T) {
+	checkMapping(t, "Planar", Planar, []UVTest{
+		{math.NewPoint(0.25, 0, 0.5), 0.25, 0.5},
+		{math.NewPoint(0.25, 0, -0.25), 0.25, 0.75},
+		{math.NewPoint(0.25, 0.5, -0.25), 0.25, 0.75},
+		{math.NewPoint(1.25, 0, 0.5), 0.25, 0.5},
+		{math.NewPoint(0.25, 0, -1.75), 0.25, 0.25},
+		{math.NewPoint(1, 0, -1), 0, 0},
+		{math.NewPoint(0, 0, 0), 0, 0},
+	})
+}
+
+func TestCylindricalMapping(t *testing.T) {
+	checkMapping(t, "Cylindrical", Cylindrical, []UVTest{
+		{math.NewPoint(0, 0, -1), 0, 0},
+		{math.NewPoint(0, 0.5, -1), 0, 0.5},
+		{math.NewPoint(0, 1, -1), 0, 0},
+		{math.NewPoint(m.Sqrt2/2, 0.5, -m.Sqrt2/2), 0.125, 0.5},
+		{math.NewPoint(1, 0.5, 0), 0.25, 0.5},
+		{math.NewPoint(m.Sqrt2/2, 0.5, m.Sqrt2/2), 0.375, 0.5},
+		{math.NewPoint(0, -0.25, 1), 0.5, 0.75},
+		{math.NewPoint(-m.Sqrt2/2, 0.5, m.Sqrt2/2), 0.625, 0.5},
+		{math.NewPoint(-1, 1.25, 0), 0.75, 0.25},
+		{math.NewPoint(-m.Sqrt2/2, 0.5, -m.Sqrt2/2), 0.875, 0.5},
+	})
+}
+
+func TestCubeFaces(t *testing.T) {
+	tests := []struct {
+		point math.Point
+		face  CubeFace
+	}{
+		{math.NewPoint(-1, 0.5, -0.25), Left},
+		{math.NewPoint(1.1, -0.75, 0.8), Right},
+		{math.NewPoint(0.1, 0.6, 0.9), Front},
+		{math.NewPoint(-0.7, 0, -2), Back},
+		{math.NewPoint(0.5, 1, 0.9), Up},
+		{math.NewPoint(-0.2, -1.3, 1.1), Down},
+	}
+	for _, test := range tests {
+		if face := FaceOf(test.point); face != test.face {
+			t.Errorf("Point %v on wrong face. Expected %v, got %v", test.point, test.face, face)
+		}
+	}
+}
+
+func TestCubeFaceMapping(t *testing.T) {
+	for face, tests := range map[CubeFace][]UVTest{
+		Front: {{math.NewPoint(-0.5, 0.5, 1), 0.25, 0.75}, {math.NewPoint(0.5, -0.5, 1), 0.75, 0.25}},
+		Back:  {{math.NewPoint(0.5, 0.5, -1), 0.25, 0.75}, {math.NewPoint(-0.5, -0.5, -1), 0.75, 0.25}},
+		Left:  {{math.NewPoint(-1, 0.5, -0.5), 0.25, 0.75}, {math.NewPoint(-1, -0.5, 0.5), 0.75, 0.25}},
+		Right: {{math.NewPoint(1, 0.5, 0.5), 0.25, 0.75}, {math.NewPoint(1, -0.5, -0.5), 0.75, 0.25}},
+		Up:    {{math.NewPoint(-0.5, 1, -0.5), 0.25, 0.75}, {math.NewPoint(0.5, 1, 0.5), 0.75, 0.25}},
+		Down:  {{math.NewPoint(-0.5, -1, 0.5), 0.25, 0.75}, {math.NewPoint(0.5, -1, -0.5), 0.75, 0.25}},
+	} {
+		face := face
+		checkMapping(t, "Cube face", func(p math.Point) (float64, float64) { return CubeFaceUV(face, p) }, tests)
+	}
+}
+
+func TestCubeFacesShader(t *testing.T) {
+	pigment := func(c color.Color) UVShader {
+		return func(u, v float64) color.Color { return c }
+	}
+	colors := []color.Color{color.Red, color.Green, color.Blue, color.White, color.Black, color.New(1, 1, 0)}
+	shader := CubeFaces(pigment(colors[0]), pigment(colors[1]), pigment(colors[2]), pigment(colors[3]), pigment(colors[4]), pigment(colors[5]))
+	points := []math.Point{
+		math.NewPoint(-1, 0, 0), math.NewPoint(0, 0, 1), math.NewPoint(1, 0, 0),
+		math.NewPoint(0, 0, -1), math.NewPoint(0, 1, 0), math.NewPoint(0, -1, 0),
+	}
+	for i, p := range points {
+		if c := shader(p); !c.Equal(colors[i]) {
+			t.Errorf("Wrong texture on face %v. Expected %v, got %v", CubeFace(i), colors[i], c)
+		}
+	}
+}
+
+func TestCubicCrossLayout(t *testing.T) {
+	checkMapping(t, "Cubic", Cubic, []UVTest{
+		// Center of the front face is the center of the second column
+		{math.NewPoint(0, 0, 1), 0.375, 0.5},
+		{math.NewPoint(0, 1, 0), 0.375, 2.5 / 3},
+		{math.NewPoint(0, -1, 0), 0.375, 0.5 / 3},
+		{math.NewPoint(0, 0, -1), 0.875, 0.5},
+	})
+}